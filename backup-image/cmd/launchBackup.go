@@ -54,24 +54,33 @@ func ParseBackupPath(BackupPath string) string {
 	return BackupPath
 }
 
-//LaunchBackup triggers the backup procedure
+//LaunchBackup triggers the backup procedure, reporting its progress, exit code and manifest on its job
 // returns:			error
-func LaunchBackup(BackupPath string) error {
+func LaunchBackup(BackupPath string) (err error) {
 
 	// check for slash in the BackupPath
 	BackupPath = ParseBackupPath(BackupPath)
 
+	// the service account of the pod isn't reachable anymore once in /host
+	reporter := NewJobReporter()
+
 	//change root directory to /host
 	if err := syscall.Chroot(host); err != nil {
 		log.Errorf("Couldn't do chroot to %s, err: %s", host, err)
+		reporter.Finish(BackupPath, err)
 		return err
 	}
 
+	defer func() {
+		reporter.Finish(BackupPath, err)
+	}()
+
 	// During recovery, this container may get relaunched, as it will be in "Running"
 	// state when the backup is taken. We'll check to see if a recovery is already
 	// in progress then, and just exit cleanly if so.
 	if RecoveryInProgress(BackupPath) {
 		log.Info("Cannot take backup. Recovery is currently in progress")
+		reporter.Progress("skipped, recovery in progress")
 		return nil
 	}
 
@@ -90,13 +99,15 @@ func LaunchBackup(BackupPath string) error {
 		}
 	}
 
-	err := Cleanup(BackupPath)
+	reporter.Progress("cleaning up old content")
+	err = Cleanup(BackupPath)
 	if err != nil {
 		log.Errorf("Old directories couldn't be deleted, err: %s\n", err)
 	}
 
 	log.Info("Old contents have been cleaned up")
 
+	reporter.Progress("writing the recovery script")
 	scriptname := filepath.Join(BackupPath, recoveryScript)
 	scriptcontent, _ := recovery_assets.Asset(fmt.Sprintf("recovery/%s", recoveryScript))
 	err = os.WriteFile(scriptname, scriptcontent, 0700)
@@ -107,6 +118,7 @@ func LaunchBackup(BackupPath string) error {
 	log.Info("Upgrade recovery script written")
 
 	// Take backup
+	reporter.Progress("taking the backup")
 	backupCmd := fmt.Sprintf("%s --take-backup --dir %s", scriptname, BackupPath)
 	err = ExecuteCmd(backupCmd)
 	if err != nil {
@@ -115,6 +127,7 @@ func LaunchBackup(BackupPath string) error {
 
 	log.Info(strings.Repeat("-", 60))
 	log.Info("backup has successfully finished ...")
+	reporter.Progress("done")
 
 	return nil
 
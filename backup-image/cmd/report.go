@@ -0,0 +1,197 @@
+/*
+ * Copyright 2022 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Annotations set by the backup job on itself, read on the hub through the managedclusterview watching the job.
+// They must match the ones of github.com/redhat-ztp/openshift-sno-upgrade-recovery/pkg/client
+const (
+	ProgressAnnotation = "sno-upgrade-recovery/progress"
+	ManifestAnnotation = "sno-upgrade-recovery/manifest"
+	ExitCodeAnnotation = "sno-upgrade-recovery/exit-code"
+)
+
+// JobNameEnv and JobNamespaceEnv hold the name and namespace of the job running the backup, set from the
+// downward API by the job template
+const (
+	JobNameEnv      = "JOB_NAME"
+	JobNamespaceEnv = "JOB_NAMESPACE"
+)
+
+const serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+// BackupItem is a file or directory saved in the backup path, as recorded in the ManifestAnnotation
+type BackupItem struct {
+	Path string `json:"path"`
+	Kind string `json:"kind,omitempty"`
+	Size int64  `json:"size,omitempty"`
+}
+
+// JobReporter annotates the job running the backup through the API server of the spoke.
+// A nil JobReporter reports nothing, so the backup can run outside of a job
+type JobReporter struct {
+	client *http.Client
+	url    string
+	token  string
+}
+
+// NewJobReporter builds a JobReporter from the job environment and the service account of the pod, it must be
+// called before the chroot to the host. It returns nil when the backup doesn't run in a job
+// returns:			*JobReporter
+func NewJobReporter() *JobReporter {
+	name, namespace := os.Getenv(JobNameEnv), os.Getenv(JobNamespaceEnv)
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if name == "" || namespace == "" || host == "" || port == "" {
+		log.Info("Not running in a job, the backup progress won't be reported")
+		return nil
+	}
+
+	token, err := os.ReadFile(filepath.Join(serviceAccountDir, "token"))
+	if err != nil {
+		log.Errorf("Couldn't read the service account token, the backup progress won't be reported, err: %s", err)
+		return nil
+	}
+	ca, err := os.ReadFile(filepath.Join(serviceAccountDir, "ca.crt"))
+	if err != nil {
+		log.Errorf("Couldn't read the service account CA, the backup progress won't be reported, err: %s", err)
+		return nil
+	}
+	pool := x509.NewCertPool()
+	pool.AppendCertsFromPEM(ca)
+
+	return &JobReporter{
+		client: &http.Client{
+			Timeout:   30 * time.Second,
+			Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}},
+		},
+		url:   fmt.Sprintf("https://%s/apis/batch/v1/namespaces/%s/jobs/%s", net.JoinHostPort(host, port), namespace, name),
+		token: string(bytes.TrimSpace(token)),
+	}
+}
+
+// Annotate merges annotations into the annotations of the job, failures are logged as the backup itself isn't affected
+func (r *JobReporter) Annotate(annotations map[string]string) {
+	if r == nil {
+		return
+	}
+
+	patch, err := json.Marshal(map[string]interface{}{"metadata": map[string]interface{}{"annotations": annotations}})
+	if err != nil {
+		log.Errorf("Couldn't encode the job annotations, err: %s", err)
+		return
+	}
+	req, err := http.NewRequest(http.MethodPatch, r.url, bytes.NewReader(patch))
+	if err != nil {
+		log.Errorf("Couldn't build the job annotations request, err: %s", err)
+		return
+	}
+	req.Header.Set("Authorization", "Bearer "+r.token)
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		log.Errorf("Couldn't annotate the job, err: %s", err)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		log.Errorf("Couldn't annotate the job, the API server answered: %s", resp.Status)
+	}
+}
+
+// Progress reports the current stage of the backup in the ProgressAnnotation of the job
+func (r *JobReporter) Progress(stage string) {
+	r.Annotate(map[string]string{ProgressAnnotation: stage})
+}
+
+// Finish reports the exit code of the backup in the ExitCodeAnnotation of the job, along with the items of
+// BackupPath in its ManifestAnnotation when the backup succeeded
+func (r *JobReporter) Finish(BackupPath string, backupErr error) {
+	if r == nil {
+		return
+	}
+
+	annotations := map[string]string{ExitCodeAnnotation: strconv.Itoa(ExitCode(backupErr))}
+	if backupErr == nil {
+		items, err := BackupManifest(BackupPath)
+		if err != nil {
+			log.Errorf("Couldn't list the backed up items, err: %s", err)
+		} else if manifest, err := json.Marshal(items); err == nil {
+			annotations[ManifestAnnotation] = string(manifest)
+		}
+	}
+	r.Annotate(annotations)
+}
+
+// ExitCode returns the exit code of the command that failed with err, 1 when err isn't an exit error
+// and 0 when err is nil
+// returns:			int
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	if exitErr, ok := err.(interface{ ExitCode() int }); ok && exitErr.ExitCode() > 0 {
+		return exitErr.ExitCode()
+	}
+	return 1
+}
+
+// BackupManifest lists the files and directories at the top of BackupPath, with their size in bytes
+// returns:			[]BackupItem, error
+func BackupManifest(BackupPath string) ([]BackupItem, error) {
+	entries, err := os.ReadDir(BackupPath)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]BackupItem, 0, len(entries))
+	for _, entry := range entries {
+		item := BackupItem{Path: filepath.Join(BackupPath, entry.Name()), Kind: "file"}
+		if entry.IsDir() {
+			item.Kind = "directory"
+		}
+		err := filepath.Walk(item.Path, func(_ string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.Mode().IsRegular() {
+				item.Size += info.Size()
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
@@ -0,0 +1,67 @@
+package cmd_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/redhat-ztp/openshift-sno-upgrade-recovery/backup-image/cmd"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JobReporter", func() {
+	Describe("ExitCode", func() {
+		Context("When the backup succeeded", func() {
+			It("returns 0", func() {
+				Expect(cmd.ExitCode(nil)).To(Equal(0))
+			})
+		})
+
+		Context("When the backup script failed", func() {
+			It("returns the exit code of the script", func() {
+				err := exec.Command("bash", "-c", "exit 3").Run()
+				Expect(cmd.ExitCode(err)).To(Equal(3))
+			})
+		})
+
+		Context("When the backup failed before running the script", func() {
+			It("returns 1", func() {
+				Expect(cmd.ExitCode(os.ErrNotExist)).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("BackupManifest", func() {
+		It("lists the top level items of the backup path with their size", func() {
+			dir, err := os.MkdirTemp("", "backup")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(dir)
+
+			Expect(os.MkdirAll(filepath.Join(dir, "etcd", "member"), 0700)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, "etcd", "member", "db"), make([]byte, 10), 0600)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, "etcd", "snapshot"), make([]byte, 5), 0600)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, "upgrade-recovery.sh"), make([]byte, 3), 0700)).To(Succeed())
+
+			items, err := cmd.BackupManifest(dir)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(items).To(Equal([]cmd.BackupItem{
+				{Path: filepath.Join(dir, "etcd"), Kind: "directory", Size: 15},
+				{Path: filepath.Join(dir, "upgrade-recovery.sh"), Kind: "file", Size: 3},
+			}))
+		})
+	})
+
+	Describe("NewJobReporter", func() {
+		Context("When the backup doesn't run in a job", func() {
+			It("returns a nil reporter that reports nothing", func() {
+				os.Unsetenv(cmd.JobNameEnv)
+				reporter := cmd.NewJobReporter()
+				Expect(reporter).To(BeNil())
+				reporter.Progress("done")
+				reporter.Finish("/nonexistent", nil)
+			})
+		})
+	})
+})
@@ -17,7 +17,10 @@
 package root
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"sync"
@@ -27,7 +30,7 @@ import (
 	metaclient1 "github.com/redhat-ztp/openshift-sno-upgrade-recovery/pkg/client"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/rest"
 
 	log "github.com/sirupsen/logrus"
 )
@@ -38,9 +41,17 @@ type Status struct {
 	ClusterError  interface{}
 }
 
+// printResults writes the run results of the spokes to w as a JSON array, for the pipelines driving the backups
+// returns:			error
+func printResults(w io.Writer, results []metaclient1.RunResult) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(results)
+}
+
 // multiSpokeLaunch initiates backup to all the provided spoke clusters concurrently
 // returns:			error
-func multiSpokeLaunch(client metaclient1.Client) error {
+func multiSpokeLaunch(ctx context.Context, client metaclient1.Client) error {
 	status := []Status{}
 	var mu sync.Mutex
 	ch := make(chan string, len(client.Spoke))
@@ -49,7 +60,7 @@ func multiSpokeLaunch(client metaclient1.Client) error {
 	for _, v := range client.Spoke {
 		wg.Add(1)
 		go func(client metaclient1.Client, v string, ch chan string, wg *sync.WaitGroup) {
-			retStatus, err := launchBackupJobs(client, v, ch, wg)
+			retStatus, err := launchBackupJobs(ctx, client, v, ch, wg)
 			mu.Lock()
 			if err != nil {
 				status = append(status, Status{v, retStatus, err})
@@ -75,79 +86,78 @@ func multiSpokeLaunch(client metaclient1.Client) error {
 
 // launchBackupJobs calls various Client functions to launch k8s jobs to trigger backup
 // returns:			Job status, error
-func launchBackupJobs(client metaclient1.Client, name string, ch chan string, wg *sync.WaitGroup) (string, error) {
+func launchBackupJobs(ctx context.Context, client metaclient1.Client, name string, ch chan string, wg *sync.WaitGroup) (string, error) {
 
 	defer wg.Done()
 
 	log.SetFormatter(&log.JSONFormatter{})
 	log.SetLevel(log.DebugLevel)
+	client.RunID = metaclient1.NewRunID()
+	logger := log.WithFields(log.Fields{"Spoke": name, "RunID": client.RunID})
+	client.Logger = logger
 	// check whether the spoke exists
-	if !client.SpokeClusterExists(name) {
-		return metaclient1.NExist, fmt.Errorf("cluster %s does not exist", name)
-
+	available, found, err := client.SpokeClusterStatus(ctx, name)
+	if err != nil {
+		return metaclient1.Failed, fmt.Errorf("couldn't check cluster %s, err: %w", name, err)
+	}
+	if !found {
+		return metaclient1.NExist, fmt.Errorf("cluster %s: %w", name, metaclient1.ErrSpokeNotFound)
+	}
+	if !available {
+		return metaclient1.Failed, fmt.Errorf("cluster %s: %w", name, metaclient1.ErrSpokeNotAvailable)
+	}
+	logger.Info("Cluster exists!")
+	select {
+	case <-ctx.Done():
+		return metaclient1.Failed, ctx.Err()
+	case <-time.After(time.Second * 2):
 	}
-	log.Info("Cluster exists!")
-	time.Sleep(time.Second * 2)
 
-	log.Info("Creating Kubernetes objects")
+	logger.Info("Creating Kubernetes objects")
 
-	err := client.LaunchKubernetesObjects(name, metaclient1.ActionCreateTemplates)
+	err = client.LaunchKubernetesObjects(ctx, name, client.Templates.ActionCreate)
 	if err != nil {
-		log.Errorf("Couldn't launch k8s ManagedClusterAction objects in the %s cluster err: %s", name, err)
-		log.Info("Deleting all mca objects")
-		if _, err = client.ManageObjects(name, metaclient1.ActionCreateTemplates, metaclient1.MCA, "delete"); err != nil {
+		logger.Errorf("Couldn't launch k8s ManagedClusterAction objects in the %s cluster err: %s", name, err)
+		logger.Info("Deleting all mca objects")
+		if _, err = client.ManageObjects(ctx, name, client.Templates.ActionCreate, metaclient1.MCA, "delete"); err != nil {
 			return metaclient1.Failed, fmt.Errorf("couldn't delete k8s ManagedClusterAction objects in the %s cluster err: %s", name, err)
 			//	return err
 		}
 		return name, err
 	}
-	log.Info("Successfully created all K8s mca objects")
+	logger.Info("Successfully created all K8s mca objects")
 
-	// create managedclusterview object
-	_, err = client.ManageObjects(name, metaclient1.ViewCreateTemplates, metaclient1.MCV, "get")
-	if err != nil {
-		if errors.IsAlreadyExists(err) {
-			_, err = client.ManageObjects(name, metaclient1.ViewCreateTemplates, metaclient1.MCV, "delete")
-			if err != nil {
-				return metaclient1.Failed, fmt.Errorf("couldn't delete existing ManagedclusterView object in the %s cluster err: %s", name, err)
-				//	return err
-			}
+	// the job action is the last one, wait for the spoke to execute it before watching the job
+	if n := len(client.Templates.ActionCreate); n > 0 {
+		if err = client.WaitForActionAccepted(ctx, name, client.Templates.ActionCreate[n-1].ResourceName); err != nil {
+			return metaclient1.Failed, fmt.Errorf("couldn't verify the job was created in the %s cluster err: %s", name, err)
 		}
-		if errors.IsNotFound(err) {
+	}
 
-			err = client.LaunchKubernetesObjects(name, metaclient1.ViewCreateTemplates)
-			if err != nil {
-				return metaclient1.Failed, fmt.Errorf("couldn't launch k8s ManagedclusterView object the %s cluster err: %s", name, err)
-				//	return err
-			}
+	// create managedclusterview object, reusing the one left by a previous run
+	for _, view := range client.Templates.ViewCreate {
+		if _, err = client.EnsureView(ctx, name, view); err != nil {
+			return metaclient1.Failed, fmt.Errorf("couldn't launch k8s ManagedclusterView object the %s cluster err: %s", name, err)
 		}
 	}
-	log.Info("Successfully created ManagedclusterView object")
+	logger.Info("Successfully created ManagedclusterView object")
 
 	// check job status via managedclusterview
-	err = client.JobStatus(name, metaclient1.Launch)
+	err = client.JobStatus(ctx, name, metaclient1.Launch)
 	if err != nil {
 		return metaclient1.Failed, fmt.Errorf("couldn't verify the initiation of the job, err: %s", err)
 	}
 
-	err = client.JobStatus(name, metaclient1.Complete)
+	err = client.JobStatus(ctx, name, metaclient1.Complete)
 	if err != nil {
 		return metaclient1.Failed, fmt.Errorf("couldn't verify if the job has finished, err: %s", err)
 	}
 
-	// delete managedclusterview
-	_, err = client.ManageObjects(name, metaclient1.ViewCreateTemplates, metaclient1.MCV, "delete")
-	if err != nil {
-		return metaclient1.Failed, fmt.Errorf("couldn't delete existing ManagedclusterView object in the %s cluster err: %s", name, err)
+	// delete managedclusterview (unless KeepViews is set) and the namespace in the spoke, which will delete the completed job and associated pod.
+	if err = client.Cleanup(ctx, name); err != nil {
+		return metaclient1.Failed, err
 	}
-
-	//delete the namespace in the spoke, which will delete the completed job and associated pod.
-	err = client.LaunchKubernetesObjects(name, metaclient1.JobDeleteTemplates)
-	if err != nil {
-		return metaclient1.Failed, fmt.Errorf("couldn't launch k8 objects in the %s cluster err: %s", name, err)
-		//	return err
-	}
-	log.Info("Successfully deleted all Kubernetes objects")
+	logger.Info("Successfully deleted all Kubernetes objects")
 
 	return metaclient1.Done, nil
 }
@@ -165,16 +175,67 @@ var triggerBackupCmd = &cobra.Command{
 			Clustername = append(Clustername, strings.TrimSpace(v))
 		}
 
+		output, _ := cmd.Flags().GetString("output")
+		if output != "table" && output != "json" {
+			return fmt.Errorf("unsupported output %q, must be table or json", output)
+		}
+
 		BackupPath, _ := cmd.Flags().GetString("BackupPath")
 		KubeconfigPath, _ := cmd.Flags().GetString("KubeconfigPath")
+		SpokeBackupPath, _ := cmd.Flags().GetStringToString("SpokeBackupPath")
 
-		client, err := metaclient1.New(Clustername, BackupPath, KubeconfigPath)
+		var caData []byte
+		if caFile, _ := cmd.Flags().GetString("CAFile"); caFile != "" {
+			var err error
+			if caData, err = os.ReadFile(caFile); err != nil {
+				return err
+			}
+		}
+		insecure, _ := cmd.Flags().GetBool("Insecure")
+		qps, _ := cmd.Flags().GetFloat32("QPS")
+		burst, _ := cmd.Flags().GetInt("Burst")
+		connectTimeout, _ := cmd.Flags().GetDuration("ConnectTimeout")
+		namespace, _ := cmd.Flags().GetString("Namespace")
+		image, _ := cmd.Flags().GetString("Image")
+		imagePullSecret, _ := cmd.Flags().GetString("ImagePullSecret")
+
+		client, err := metaclient1.NewWithOptions(Clustername,
+			metaclient1.WithKubeconfigPath(KubeconfigPath),
+			metaclient1.WithBackupPath(BackupPath),
+			metaclient1.WithBackupPaths(SpokeBackupPath),
+			metaclient1.WithTLS(caData, insecure),
+			metaclient1.WithRateLimits(qps, burst),
+			metaclient1.WithConnectTimeout(connectTimeout),
+			metaclient1.WithNamespace(namespace),
+			metaclient1.WithImage(image, imagePullSecret),
+		)
 		if err != nil {
 			return err
 		}
+		defer client.Close()
+		client.KeepViews, _ = cmd.Flags().GetBool("KeepViews")
+		client.StatusOptions.Timeout, _ = cmd.Flags().GetDuration("Timeout")
+		client.StatusOptions.Interval, _ = cmd.Flags().GetDuration("PollInterval")
+		client.StatusOptions.Jitter, _ = cmd.Flags().GetFloat64("PollJitter")
+
+		if err = client.Ping(cmd.Context()); err != nil {
+			return err
+		}
+
+		if output == "json" {
+			// the logs go to stderr, stdout only gets the results
+			log.SetFormatter(&log.JSONFormatter{})
+			log.SetLevel(log.DebugLevel)
+			log.Infof("Backup will be launched on clusters: %s", client.Spoke)
+			results, err := client.BackupAll(cmd.Context())
+			if printErr := printResults(os.Stdout, results); printErr != nil {
+				return printErr
+			}
+			return err
+		}
 
 		//	err = launchBackupJobs(client)
-		err = multiSpokeLaunch(client)
+		err = multiSpokeLaunch(cmd.Context(), client)
 		if err != nil {
 			return err
 		}
@@ -199,10 +260,40 @@ func init() {
 		return
 	}
 
-	triggerBackupCmd.Flags().StringP("BackupPath", "p", "/var/recovery", "Path of recovery partition where backups will be stored")
+	triggerBackupCmd.Flags().StringP("BackupPath", "p", metaclient1.DefaultBackupPath, "Path of recovery partition where backups will be stored")
+	triggerBackupCmd.Flags().String("CAFile", "", "Path to a CA bundle used to verify the hub instead of the kubeconfig one")
+	triggerBackupCmd.Flags().Bool("Insecure", false, "Skip the verification of the hub certificate")
+	triggerBackupCmd.Flags().Float32("QPS", rest.DefaultQPS, "Maximum queries per second to the hub")
+	triggerBackupCmd.Flags().Int("Burst", rest.DefaultBurst, "Maximum burst of queries to the hub")
+	triggerBackupCmd.Flags().Duration("ConnectTimeout", metaclient1.DefaultConnectTimeout, "Maximum time to connect to the hub")
+	triggerBackupCmd.Flags().StringToString("SpokeBackupPath", nil, "Per spoke backup path overriding BackupPath, e.g. spoke1=/var/recovery1,spoke2=/var/recovery2")
+	triggerBackupCmd.Flags().StringP("Namespace", "n", metaclient1.DefaultNamespace, "Namespace created on the spoke cluster to run the backup job")
+	triggerBackupCmd.Flags().StringP("Image", "i", metaclient1.DefaultImage, "Image running the backup job on the spoke cluster")
+	triggerBackupCmd.Flags().String("ImagePullSecret", "", "Name of the secret used to pull the backup image on the spoke cluster")
+	triggerBackupCmd.Flags().Bool("KeepViews", false, "Keep the ManagedclusterViews of the completed jobs on the hub as an audit trail")
+	triggerBackupCmd.Flags().StringP("output", "o", "table", "Format of the results, table or json to print the run result of every spoke to stdout")
+
+	defaults := metaclient1.DefaultCheckStatusOptions()
+	triggerBackupCmd.Flags().Duration("Timeout", defaults.Timeout, "Maximum time to wait for the backup job to report its status")
+	triggerBackupCmd.Flags().Duration("PollInterval", defaults.Interval, "Interval between two checks of the backup job status")
+	triggerBackupCmd.Flags().Float64("PollJitter", defaults.Jitter, "Fraction of PollInterval by which every check is randomly moved, 0 to check on a fixed cadence")
 
 	// bind to viper
 	_ = viper.BindPFlag("Spoke", triggerBackupCmd.Flags().Lookup("Spoke"))
 	_ = viper.BindPFlag("BackupPath", triggerBackupCmd.Flags().Lookup("BackupPath"))
 	_ = viper.BindPFlag("KubeconfigPath", triggerBackupCmd.Flags().Lookup("KubeconfigPath"))
+	_ = viper.BindPFlag("CAFile", triggerBackupCmd.Flags().Lookup("CAFile"))
+	_ = viper.BindPFlag("Insecure", triggerBackupCmd.Flags().Lookup("Insecure"))
+	_ = viper.BindPFlag("QPS", triggerBackupCmd.Flags().Lookup("QPS"))
+	_ = viper.BindPFlag("Burst", triggerBackupCmd.Flags().Lookup("Burst"))
+	_ = viper.BindPFlag("ConnectTimeout", triggerBackupCmd.Flags().Lookup("ConnectTimeout"))
+	_ = viper.BindPFlag("SpokeBackupPath", triggerBackupCmd.Flags().Lookup("SpokeBackupPath"))
+	_ = viper.BindPFlag("Namespace", triggerBackupCmd.Flags().Lookup("Namespace"))
+	_ = viper.BindPFlag("Image", triggerBackupCmd.Flags().Lookup("Image"))
+	_ = viper.BindPFlag("ImagePullSecret", triggerBackupCmd.Flags().Lookup("ImagePullSecret"))
+	_ = viper.BindPFlag("KeepViews", triggerBackupCmd.Flags().Lookup("KeepViews"))
+	_ = viper.BindPFlag("output", triggerBackupCmd.Flags().Lookup("output"))
+	_ = viper.BindPFlag("Timeout", triggerBackupCmd.Flags().Lookup("Timeout"))
+	_ = viper.BindPFlag("PollInterval", triggerBackupCmd.Flags().Lookup("PollInterval"))
+	_ = viper.BindPFlag("PollJitter", triggerBackupCmd.Flags().Lookup("PollJitter"))
 }
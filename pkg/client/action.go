@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// actionCompleted is the condition type set on a managedclusteraction once processed by the spoke
+	actionCompleted = "Completed"
+	// actionDone is the reason of the completed condition when the action succeeded
+	actionDone = "ActionDone"
+)
+
+// CheckActionStatus checks whether the managedclusteraction named actionName was executed on the spoke
+// returns: 	completed bool, error carrying the spoke message when the action failed
+func (c Client) CheckActionStatus(ctx context.Context, clusterName string, actionName string) (bool, error) {
+
+	gvr, err := c.resourceGVR(MCA)
+	if err != nil {
+		return false, err
+	}
+
+	var action *unstructured.Unstructured
+	err = c.withRetry(ctx, func() error {
+		opCtx, cancel := c.operationContext(ctx)
+		defer cancel()
+		var err error
+		action, err = c.KubernetesClient.Resource(gvr).Namespace(clusterName).Get(opCtx, actionName, v1.GetOptions{})
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+
+	conditions, exists, err := unstructured.NestedSlice(action.Object, "status", "conditions")
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		c.logger().Debugf("managedclusteraction %s for cluster %s has no condition yet", actionName, clusterName)
+		return false, nil
+	}
+
+	for _, condition := range conditions {
+		cond, ok := condition.(map[string]interface{})
+		if !ok || cond["type"] != actionCompleted {
+			continue
+		}
+		if cond["status"] != "True" {
+			return false, nil
+		}
+		if cond["reason"] != actionDone {
+			return false, fmt.Errorf("%w: action %s on cluster %s: %v", ErrActionFailed, actionName, clusterName, cond["message"])
+		}
+		c.logger().Debugf("managedclusteraction %s for cluster %s is done", actionName, clusterName)
+		return true, nil
+	}
+	return false, nil
+}
+
+// WaitForActionAccepted polls the managedclusteraction named actionName until the spoke executed it,
+// it should be called before JobStatus so the view isn't polled while the job is not created yet.
+// The window and polling cadence are taken from c.StatusOptions
+// returns: 	error, wrapping ErrActionFailed or ErrActionTimeout
+func (c Client) WaitForActionAccepted(ctx context.Context, clusterName string, actionName string) error {
+
+	return c.pollUntil(ctx, fmt.Errorf("%w: action %s on cluster %s", ErrActionTimeout, actionName, clusterName), func(ctx context.Context) (bool, error) {
+		done, err := c.CheckActionStatus(ctx, clusterName, actionName)
+		if errors.IsNotFound(err) {
+			c.logger().Debugf("managedclusteraction %s for cluster %s not found yet", actionName, clusterName)
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if done {
+			c.logger().WithFields(log.Fields{"ActionStatus": "Accepted"}).Debugf("managedclusteraction %s was executed on cluster %s", actionName, clusterName)
+		}
+		return done, nil
+	})
+}
@@ -0,0 +1,135 @@
+package client
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Backup runs the whole backup sequence on clusterName: it verifies the spoke is available, deletes the resources
+// left on the hub by a previous run that didn't clean up, creates the managedclusteractions launching the job,
+// creates the managedclusterview watching it and waits for the job to finish. When CleanupAfterBackup is set
+// the created resources are cleaned up afterwards and the backup namespace is waited for to be gone from the spoke. With DryRun or ServerDryRun the run stops
+// once the resources are rendered, nothing being created to wait for. The run is identified by RunID,
+// generated when the client has none
+// returns:			RunResult, error
+func (c Client) Backup(ctx context.Context, clusterName string) (RunResult, error) {
+	c = c.withRun()
+	res := RunResult{Cluster: clusterName, RunID: c.RunID}
+
+	start := time.Now()
+	err := c.backup(ctx, clusterName, &res)
+	res.finish(start, err)
+	return res, err
+}
+
+// backup runs the backup sequence of Backup, recording its progress in res
+// returns:			error
+func (c Client) backup(ctx context.Context, clusterName string, res *RunResult) error {
+	templates := c.templates()
+
+	c.enterPhase(res, clusterName, PhaseSpokeCheck)
+
+	available, found, err := c.SpokeClusterStatus(ctx, clusterName)
+	if err != nil {
+		return fmt.Errorf("couldn't check cluster %s, err: %w", clusterName, err)
+	}
+	if !found {
+		return fmt.Errorf("cluster %s: %w", clusterName, ErrSpokeNotFound)
+	}
+	if !available {
+		return fmt.Errorf("cluster %s: %w", clusterName, ErrSpokeNotAvailable)
+	}
+
+	c.logger().WithFields(log.Fields{"Backup": "Launching"}).Infof("Launching backup to %s on cluster: %s", c.backupPath(clusterName), clusterName)
+
+	c.enterPhase(res, clusterName, PhaseLaunch)
+	if !c.dryRun() {
+		if err := c.deleteLeftovers(ctx, clusterName); err != nil {
+			return err
+		}
+	}
+	if err := c.LaunchKubernetesObjects(ctx, clusterName, templates.ActionCreate); err != nil {
+		return fmt.Errorf("couldn't launch k8s ManagedClusterAction objects in the %s cluster err: %w", clusterName, err)
+	}
+
+	if c.dryRun() {
+		// nothing was created on the hub, so there's no action or job to wait for
+		if err := c.LaunchKubernetesObjects(ctx, clusterName, templates.ViewCreate); err != nil {
+			return fmt.Errorf("couldn't launch k8s ManagedclusterView object in the %s cluster err: %w", clusterName, err)
+		}
+		c.logger().WithFields(log.Fields{"Backup": "DryRun"}).Infof("Backup resources were rendered without being created for cluster: %s", clusterName)
+		c.enterPhase(res, clusterName, PhaseDone)
+		return nil
+	}
+
+	if err := c.waitForActions(ctx, clusterName, res, templates.ActionCreate); err != nil {
+		return err
+	}
+
+	for _, view := range templates.ViewCreate {
+		if _, err := c.EnsureView(ctx, clusterName, view); err != nil {
+			return fmt.Errorf("couldn't launch k8s ManagedclusterView object in the %s cluster err: %w", clusterName, err)
+		}
+	}
+
+	if err := c.waitForViewStatus(ctx, clusterName, templates.ViewCreate, Launch); err != nil {
+		return fmt.Errorf("couldn't verify the initiation of the job, err: %w", err)
+	}
+
+	c.enterPhase(res, clusterName, PhaseJobLaunched)
+	if err := c.waitForViewStatus(ctx, clusterName, templates.ViewCreate, Complete); err != nil {
+		return fmt.Errorf("couldn't verify if the job has finished, err: %w", err)
+	}
+
+	c.enterPhase(res, clusterName, PhaseJobCompleted)
+	output, err := c.viewResult(ctx, clusterName, templates.ViewCreate)
+	if err != nil {
+		return fmt.Errorf("couldn't read the job result, err: %w", err)
+	}
+	res.Output = output
+	res.Job = DecodeJobResult(output)
+
+	if c.CleanupAfterBackup {
+		c.enterPhase(res, clusterName, PhaseCleanup)
+		if err := c.Cleanup(ctx, clusterName); err != nil {
+			return err
+		}
+		if err := c.WaitForNamespaceDeleted(ctx, clusterName); err != nil {
+			return err
+		}
+	}
+
+	c.logger().WithFields(log.Fields{"Backup": "Done"}).Infof("Backup has successfully finished on cluster: %s", clusterName)
+	c.enterPhase(res, clusterName, PhaseDone)
+	return nil
+}
+
+// waitForActions waits for the spoke to execute every managedclusteraction of actions in order, entering the phase
+// ended by each of them. The job action is the last one, only its failure fails the run since the namespace and
+// role bindings may be left by a previous run
+// returns:			error
+func (c Client) waitForActions(ctx context.Context, clusterName string, res *RunResult, actions []ResourceTemplate) error {
+	for i, action := range actions {
+		err := c.WaitForActionAccepted(ctx, clusterName, action.ResourceName)
+		if goerrors.Is(err, ErrActionFailed) && i < len(actions)-1 {
+			c.logger().WithFields(log.Fields{"Backup": "ActionFailed"}).Infof("%s, carrying on", err)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("couldn't verify the action %s was executed in the %s cluster err: %w", action.ResourceName, clusterName, err)
+		}
+
+		objs, err := c.ManageObjects(ctx, clusterName, []ResourceTemplate{action}, MCA, "get")
+		if err != nil {
+			return err
+		}
+		if phase := actionPhase(objs[0]); phase != "" {
+			c.enterPhase(res, clusterName, phase)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package client
+
+import (
+	"context"
+	goerrors "errors"
+	"io/ioutil"
+	"reflect"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// newSpokeHub returns a fakeHub serving the available managedcluster spoke, the actions and views created on it
+// are executed at once like the spoke would, the actions named in failing reporting a failure
+func newSpokeHub(t *testing.T, failing ...string) *fakeHub {
+	hub := &fakeHub{objects: map[string]*unstructured.Unstructured{}}
+
+	cluster := &unstructured.Unstructured{}
+	cluster.SetAPIVersion(ManagedClusterGVR.GroupVersion().String())
+	cluster.SetKind("ManagedCluster")
+	cluster.SetName("spoke")
+	if err := unstructured.SetNestedSlice(cluster.Object, []interface{}{
+		map[string]interface{}{"type": "ManagedClusterConditionAvailable", "status": "True"},
+	}, "status", "conditions"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := hub.Resource(ManagedClusterGVR).Create(context.Background(), cluster, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	hub.execute = func(obj *unstructured.Unstructured) {
+		var err error
+		switch obj.GetKind() {
+		case "ManagedClusterAction":
+			reason := actionDone
+			for _, name := range failing {
+				if obj.GetName() == name {
+					reason = "ActionFailed"
+				}
+			}
+			err = unstructured.SetNestedSlice(obj.Object, []interface{}{
+				map[string]interface{}{"type": actionCompleted, "status": "True", "reason": reason, "message": "executed"},
+			}, "status", "conditions")
+		case "ManagedClusterView":
+			// the viewed job carries the run of the view, so the tests can tell which run it belongs to
+			err = unstructured.SetNestedMap(obj.Object, map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Processing", "status": "True"},
+				},
+				"result": map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"labels": map[string]interface{}{RunIDLabel: obj.GetLabels()[RunIDLabel]},
+					},
+					"status": map[string]interface{}{
+						"conditions": []interface{}{
+							map[string]interface{}{"type": "Complete", "status": "True"},
+						},
+					},
+				},
+			}, "status")
+		}
+		if err != nil {
+			t.Error(err)
+		}
+	}
+	return hub
+}
+
+// newBackupClient returns a client backing up the spoke of hub, polling it without delay
+func newBackupClient(t *testing.T, hub *fakeHub) Client {
+	c, err := NewWithClients([]string{"spoke"}, DefaultBackupPath, hub, &flakyDiscovery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := log.New()
+	logger.SetOutput(ioutil.Discard)
+	c.Logger = logger
+	c.StatusOptions = CheckStatusOptions{Timeout: 5 * time.Second, Interval: time.Millisecond}
+	c.RetryPolicy = ConstantBackoff{Attempts: 3, Delay: time.Millisecond}
+	c.CleanupAfterBackup = false
+	return c
+}
+
+func TestBackupPhases(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		failing []string
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "all actions executed",
+			want: []string{PhaseSpokeCheck, PhaseLaunch, PhaseNamespaceAccepted, PhaseRBACAccepted, PhaseJobAccepted, PhaseJobLaunched, PhaseJobCompleted, PhaseDone},
+		},
+		{
+			name:    "namespace left by a previous run",
+			failing: []string{"backup-create-namespace"},
+			want:    []string{PhaseSpokeCheck, PhaseLaunch, PhaseRBACAccepted, PhaseJobAccepted, PhaseJobLaunched, PhaseJobCompleted, PhaseDone},
+		},
+		{
+			name:    "job action failed",
+			failing: []string{"backup-create-job"},
+			want:    []string{PhaseSpokeCheck, PhaseLaunch, PhaseNamespaceAccepted, PhaseRBACAccepted},
+			wantErr: ErrActionFailed,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newBackupClient(t, newSpokeHub(t, tc.failing...))
+			var phases []string
+			c.OnPhase = func(phase string, _ string) {
+				phases = append(phases, phase)
+			}
+
+			res, err := c.Backup(context.Background(), "spoke")
+			if !goerrors.Is(err, tc.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tc.wantErr)
+			}
+			if !reflect.DeepEqual(phases, tc.want) {
+				t.Errorf("got phases %v, want %v", phases, tc.want)
+			}
+			if res.Phase != tc.want[len(tc.want)-1] {
+				t.Errorf("got phase %s, want %s", res.Phase, tc.want[len(tc.want)-1])
+			}
+		})
+	}
+}
+
+func TestBackupAfterTimedOutRun(t *testing.T) {
+	hub := newSpokeHub(t)
+
+	// a run that timed out leaves its actions and views on the hub, its view reporting its own job
+	previous := newBackupClient(t, hub)
+	previous.RunID = "timed-out-run"
+	templates := previous.templates()
+	for _, set := range [][]ResourceTemplate{templates.ActionCreate, templates.ViewCreate, templates.JobDelete} {
+		if err := previous.LaunchKubernetesObjects(context.Background(), "spoke", set); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c := newBackupClient(t, hub)
+	res, err := c.Backup(context.Background(), "spoke")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runID, _, _ := unstructured.NestedString(res.Output, "metadata", "labels", RunIDLabel)
+	if runID != res.RunID {
+		t.Errorf("got the job of run %q, want the one of run %q", runID, res.RunID)
+	}
+	actions, err := c.ManageObjects(context.Background(), "spoke", templates.ActionCreate, MCA, "get")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, action := range actions {
+		if got := action.GetLabels()[RunIDLabel]; got != res.RunID {
+			t.Errorf("action %s belongs to run %q, want %q", action.GetName(), got, res.RunID)
+		}
+	}
+	jobDelete, err := c.ManageObjects(context.Background(), "spoke", templates.JobDelete, MCA, "getIfExists")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jobDelete[0] != nil {
+		t.Errorf("the namespace deleting action of the previous run is still there")
+	}
+}
@@ -0,0 +1,175 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// NamespaceViewTemplates populates templates for creation of managedclusterview resource watching the backup namespace
+var NamespaceViewTemplates = []ResourceTemplate{
+	{"backup-namespace-view", mngClusterViewNamespace},
+}
+
+// Cleanup deletes the managedclusterviews and managedclusteractions created from the templates, then
+// launches the managedclusteraction deleting the backup namespace on the spoke. Resources already gone are ignored.
+// The namespace deleting action is left on the hub until the next Cleanup, which deletes it before launching it again.
+// When c.KeepViews is set the managedclusterviews are left on the hub, keeping the last reported status of the jobs
+// as an audit trail, they're labeled with ManagedByLabel and RunIDLabel to be listed or deleted later
+// returns:			error
+func (c Client) Cleanup(ctx context.Context, clusterName string) error {
+
+	templates := c.templates()
+	if c.KeepViews {
+		c.logger().Infof("keeping the ManagedclusterViews in the %s cluster", clusterName)
+	} else {
+		for _, view := range templates.ViewCreate {
+			if err := c.DeleteView(ctx, clusterName, view.ResourceName); err != nil {
+				return fmt.Errorf("couldn't delete ManagedclusterView %s in the %s cluster err: %w", view.ResourceName, clusterName, err)
+			}
+		}
+	}
+
+	// an action left by a previous cleanup would be skipped as already existing instead of being executed again
+	if err := c.deleteActions(ctx, clusterName, templates.ActionCreate, AbortTemplates, templates.JobDelete); err != nil {
+		return err
+	}
+
+	// the namespace holds the job and its pod, so it's deleted last
+	if err := c.LaunchKubernetesObjects(ctx, clusterName, templates.JobDelete); err != nil {
+		return fmt.Errorf("couldn't delete the %s namespace in the %s cluster err: %w", c.targetNamespace(), clusterName, err)
+	}
+
+	c.logger().WithFields(log.Fields{"Cleanup": "Done"}).Debugf("Cleaned up recovery resources for cluster: %s", clusterName)
+	return nil
+}
+
+// deleteLeftovers deletes the managedclusteractions and managedclusterviews left on the hub by a previous run that
+// didn't clean up, e.g. one that timed out. Otherwise the actions would be skipped as already existing instead of
+// being executed for this run and the views would report the job of the previous run. Resources already gone are ignored
+// returns:			error
+func (c Client) deleteLeftovers(ctx context.Context, clusterName string) error {
+
+	templates := c.templates()
+	views, err := c.ManageObjects(ctx, clusterName, templates.ViewCreate, MCV, "getIfExists")
+	if err != nil {
+		return fmt.Errorf("couldn't get the ManagedclusterViews in the %s cluster err: %w", clusterName, err)
+	}
+	for i, view := range views {
+		if view == nil {
+			continue
+		}
+		name := templates.ViewCreate[i].ResourceName
+		c.logger().WithFields(log.Fields{"Backup": "Leftover"}).Infof("deleting the ManagedclusterView %s left by run %q in the %s cluster", name, view.GetLabels()[RunIDLabel], clusterName)
+		if err := c.DeleteView(ctx, clusterName, name); err != nil {
+			return fmt.Errorf("couldn't delete ManagedclusterView %s in the %s cluster err: %w", name, clusterName, err)
+		}
+		// the view is created again right after, so it must be gone first
+		if err := c.WaitForViewDeleted(ctx, clusterName, name, 0); err != nil {
+			return err
+		}
+	}
+
+	return c.deleteActions(ctx, clusterName, templates.ActionCreate, templates.JobDelete)
+}
+
+// deleteActions deletes the managedclusteractions of every set of templates, the ones already gone are ignored
+// returns:			error
+func (c Client) deleteActions(ctx context.Context, clusterName string, templates ...[]ResourceTemplate) error {
+	for _, actions := range templates {
+		for _, action := range actions {
+			_, err := c.ManageObjects(ctx, clusterName, []ResourceTemplate{action}, MCA, "delete")
+			if err != nil && !errors.IsNotFound(err) {
+				return fmt.Errorf("couldn't delete ManagedclusterAction %s in the %s cluster err: %w", action.ResourceName, clusterName, err)
+			}
+		}
+	}
+	return nil
+}
+
+// AbortTemplates populates templates for creation of managedclusteraction resource deleting the backup job in the spoke
+var AbortTemplates = []ResourceTemplate{
+	{"backup-abort-job", mngClusterActDeleteJob},
+}
+
+// AbortBackup stops the backup running on the spoke: it launches the managedclusteraction deleting the backup job and
+// deletes the managedclusterviews watching it. It can be called whether the job is still running, finished or gone,
+// the pods left by the job are removed with the backup namespace by Cleanup
+// returns:			error
+func (c Client) AbortBackup(ctx context.Context, clusterName string) error {
+
+	// an action left by a previous abort would be skipped as already existing instead of being executed again
+	_, err := c.ManageObjects(ctx, clusterName, AbortTemplates, MCA, "delete")
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("couldn't delete the previous abort ManagedclusterAction in the %s cluster err: %w", clusterName, err)
+	}
+
+	if err := c.LaunchKubernetesObjects(ctx, clusterName, AbortTemplates); err != nil {
+		return fmt.Errorf("couldn't delete the backup job in the %s cluster err: %w", clusterName, err)
+	}
+
+	for _, view := range c.templates().ViewCreate {
+		if err := c.DeleteView(ctx, clusterName, view.ResourceName); err != nil {
+			return fmt.Errorf("couldn't delete ManagedclusterView %s in the %s cluster err: %w", view.ResourceName, clusterName, err)
+		}
+	}
+
+	c.logger().WithFields(log.Fields{"AbortBackup": "Done"}).Infof("Aborted the backup on cluster: %s", clusterName)
+	return nil
+}
+
+// WaitForNamespaceDeleted creates a managedclusterview on the backup namespace of the spoke and polls it
+// until the spoke reports the namespace doesn't exist anymore, the view is deleted before returning.
+// The window and polling cadence are taken from c.StatusOptions
+// returns:			error, wrapping ErrViewTimeout when the namespace is still there
+func (c Client) WaitForNamespaceDeleted(ctx context.Context, clusterName string) error {
+
+	if err := c.LaunchKubernetesObjects(ctx, clusterName, NamespaceViewTemplates); err != nil {
+		return fmt.Errorf("couldn't launch the namespace ManagedclusterView in the %s cluster err: %w", clusterName, err)
+	}
+	defer func() {
+		if err := c.DeleteView(context.Background(), clusterName, NamespaceViewTemplates[0].ResourceName); err != nil {
+			c.logger().Errorf("couldn't delete the namespace ManagedclusterView in the %s cluster err: %s", clusterName, err)
+		}
+	}()
+
+	return c.pollUntil(ctx, fmt.Errorf("%w: namespace %s still exists on cluster %s", ErrViewTimeout, c.targetNamespace(), clusterName), func(ctx context.Context) (bool, error) {
+		views, err := c.ManageObjects(ctx, clusterName, NamespaceViewTemplates, MCV, "get")
+		if isRetryable(err) {
+			return false, err
+		}
+		if err != nil {
+			c.logger().Debugf("couldn't get the namespace ManagedclusterView of cluster %s: %s", clusterName, err)
+			return false, nil
+		}
+		if !namespaceGone(views[0]) {
+			return false, nil
+		}
+		c.logger().WithFields(log.Fields{"Cleanup": "NamespaceDeleted"}).Debugf("Namespace %s was deleted on cluster: %s", c.targetNamespace(), clusterName)
+		return true, nil
+	})
+}
+
+// namespaceGone checks whether the managedclusterview reports it couldn't find the watched namespace
+// returns:			bool
+func namespaceGone(view *unstructured.Unstructured) bool {
+	conditions, _, err := unstructured.NestedSlice(view.Object, "status", "conditions")
+	if err != nil {
+		return false
+	}
+	for _, condition := range conditions {
+		cond, ok := condition.(map[string]interface{})
+		if !ok || cond["type"] != "Processing" || cond["status"] != "False" {
+			continue
+		}
+		msg, _ := cond["message"].(string)
+		if cond["reason"] == "GetResourceFailed" && strings.Contains(msg, "not found") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package client
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCleanupIsIdempotent(t *testing.T) {
+	hub := newSpokeHub(t)
+	c := newBackupClient(t, hub)
+	c.RunID = "run"
+	templates := c.templates()
+	for _, set := range [][]ResourceTemplate{templates.ActionCreate, templates.ViewCreate} {
+		if err := c.LaunchKubernetesObjects(context.Background(), "spoke", set); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the second cleanup finds the resources already gone and the namespace deleting action already there
+	for i := 0; i < 2; i++ {
+		if err := c.Cleanup(context.Background(), "spoke"); err != nil {
+			t.Fatalf("cleanup %d: %s", i+1, err)
+		}
+	}
+
+	for _, set := range []struct {
+		resourceType string
+		templates    []ResourceTemplate
+	}{{MCA, templates.ActionCreate}, {MCV, templates.ViewCreate}} {
+		objs, err := c.ManageObjects(context.Background(), "spoke", set.templates, set.resourceType, "getIfExists")
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, obj := range objs {
+			if obj != nil {
+				t.Errorf("%s %s is still there", set.resourceType, set.templates[i].ResourceName)
+			}
+		}
+	}
+	if _, err := c.ManageObjects(context.Background(), "spoke", templates.JobDelete, MCA, "get"); err != nil {
+		t.Errorf("got %s, want the namespace deleting action to be launched", err)
+	}
+}
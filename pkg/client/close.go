@@ -0,0 +1,42 @@
+package client
+
+import (
+	"net/http"
+
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+)
+
+// Close releases the cached discovery data, the RESTMapper and the idle connections of the transport
+// shared by the kubernetes clients. The client must be rebuilt with New before being used again; calling
+// Close more than once is a no-op
+// returns:			error
+func (c *Client) Close() error {
+	if c.DiscoveryClient != nil {
+		c.DiscoveryClient.Invalidate()
+	}
+
+	// the transport is shared by the dynamic and discovery clients built by initClients
+	closeIdleConnections(c.transport)
+
+	c.KubernetesClient = nil
+	c.DiscoveryClient = nil
+	c.RESTMapper = nil
+	c.transport = nil
+	c.Config = nil
+	return nil
+}
+
+// closeIdleConnections closes the idle connections of transport, unwrapping the client-go round trippers
+func closeIdleConnections(transport http.RoundTripper) {
+	for transport != nil {
+		if closer, ok := transport.(interface{ CloseIdleConnections() }); ok {
+			closer.CloseIdleConnections()
+			return
+		}
+		wrapper, ok := transport.(utilnet.RoundTripperWrapper)
+		if !ok {
+			return
+		}
+		transport = wrapper.WrappedRoundTripper()
+	}
+}
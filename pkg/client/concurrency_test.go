@@ -0,0 +1,152 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+)
+
+// fakeHub is an in-memory dynamic client holding the resources created on the hub, execute is called
+// on every created resource when set, e.g. to report the status set by the spoke
+type fakeHub struct {
+	mu      sync.Mutex
+	objects map[string]*unstructured.Unstructured
+	execute func(obj *unstructured.Unstructured)
+}
+
+func (h *fakeHub) Resource(gvr schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	return fakeResource{hub: h, gvr: gvr}
+}
+
+// fakeResource implements the calls done by LaunchKubernetesObjects, ManageObjects and Backup, the other ones panic
+type fakeResource struct {
+	dynamic.NamespaceableResourceInterface
+	hub       *fakeHub
+	gvr       schema.GroupVersionResource
+	namespace string
+}
+
+func (r fakeResource) Namespace(namespace string) dynamic.ResourceInterface {
+	r.namespace = namespace
+	return r
+}
+
+func (r fakeResource) key(name string) string {
+	return r.gvr.String() + "/" + r.namespace + "/" + name
+}
+
+func (r fakeResource) Create(_ context.Context, obj *unstructured.Unstructured, _ metav1.CreateOptions, _ ...string) (*unstructured.Unstructured, error) {
+	r.hub.mu.Lock()
+	defer r.hub.mu.Unlock()
+	if _, ok := r.hub.objects[r.key(obj.GetName())]; ok {
+		return nil, errors.NewAlreadyExists(r.gvr.GroupResource(), obj.GetName())
+	}
+	created := obj.DeepCopy()
+	if r.hub.execute != nil {
+		r.hub.execute(created)
+	}
+	r.hub.objects[r.key(obj.GetName())] = created
+	return obj, nil
+}
+
+func (r fakeResource) Get(_ context.Context, name string, _ metav1.GetOptions, _ ...string) (*unstructured.Unstructured, error) {
+	r.hub.mu.Lock()
+	defer r.hub.mu.Unlock()
+	obj, ok := r.hub.objects[r.key(name)]
+	if !ok {
+		return nil, errors.NewNotFound(r.gvr.GroupResource(), name)
+	}
+	return obj.DeepCopy(), nil
+}
+
+func (r fakeResource) Delete(_ context.Context, name string, _ metav1.DeleteOptions, _ ...string) error {
+	r.hub.mu.Lock()
+	defer r.hub.mu.Unlock()
+	if _, ok := r.hub.objects[r.key(name)]; !ok {
+		return errors.NewNotFound(r.gvr.GroupResource(), name)
+	}
+	delete(r.hub.objects, r.key(name))
+	return nil
+}
+
+// flakyDiscovery serves the ACM resources but fails its first discoveries of the groups,
+// so the RESTMapper gets reset while other workers use it
+type flakyDiscovery struct {
+	discovery.DiscoveryInterface
+	calls    int32
+	failures int32
+}
+
+func (d *flakyDiscovery) ServerGroups() (*metav1.APIGroupList, error) {
+	if atomic.AddInt32(&d.calls, 1) <= d.failures {
+		return nil, errors.NewServiceUnavailable("discovery is flaky")
+	}
+	list := &metav1.APIGroupList{}
+	for _, gvr := range []schema.GroupVersionResource{ManagedClusterActionGVR, ManagedClusterViewGVR} {
+		version := metav1.GroupVersionForDiscovery{GroupVersion: gvr.GroupVersion().String(), Version: gvr.Version}
+		list.Groups = append(list.Groups, metav1.APIGroup{Name: gvr.Group, Versions: []metav1.GroupVersionForDiscovery{version}, PreferredVersion: version})
+	}
+	return list, nil
+}
+
+func (d *flakyDiscovery) ServerResourcesForGroupVersion(groupVersion string) (*metav1.APIResourceList, error) {
+	switch groupVersion {
+	case ManagedClusterActionGVR.GroupVersion().String():
+		return &metav1.APIResourceList{GroupVersion: groupVersion, APIResources: []metav1.APIResource{{Name: MCA, Kind: "ManagedClusterAction", Namespaced: true}}}, nil
+	case ManagedClusterViewGVR.GroupVersion().String():
+		return &metav1.APIResourceList{GroupVersion: groupVersion, APIResources: []metav1.APIResource{{Name: MCV, Kind: "ManagedClusterView", Namespaced: true}}}, nil
+	}
+	return nil, errors.NewNotFound(schema.GroupResource{}, groupVersion)
+}
+
+// TestConcurrentLaunches launches the templates on many spokes at once while the flaky discovery makes the
+// workers reset the shared RESTMapper. The RESTMapper and the discovery cache lock themselves, the test checks
+// the copies of the client sharing them stay safe when run with -race
+func TestConcurrentLaunches(t *testing.T) {
+	spokes := make([]string, 32)
+	for i := range spokes {
+		spokes[i] = fmt.Sprintf("spoke-%d", i)
+	}
+	hub := &fakeHub{objects: map[string]*unstructured.Unstructured{}}
+
+	disc := &flakyDiscovery{failures: 20}
+	c, err := NewWithClients(spokes, DefaultBackupPath, hub, disc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := log.New()
+	logger.SetOutput(ioutil.Discard)
+	c.Logger = logger
+	c.Concurrency = 8
+	c.RetryPolicy = ConstantBackoff{Attempts: 50, Delay: time.Millisecond}
+
+	results, err := c.LaunchAllSpokes(context.Background(), append(c.Templates.ActionCreate, c.Templates.ViewCreate...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, result := range results {
+		if result.Err != nil {
+			t.Errorf("spoke %s: %s", result.Name, result.Err)
+		}
+	}
+
+	if calls := atomic.LoadInt32(&disc.calls); calls <= disc.failures {
+		t.Errorf("got %d discoveries, the RESTMapper wasn't reset after the failures", calls)
+	}
+	want := len(spokes) * (len(c.Templates.ActionCreate) + len(c.Templates.ViewCreate))
+	if len(hub.objects) != want {
+		t.Errorf("got %d resources on the hub, want %d", len(hub.objects), want)
+	}
+}
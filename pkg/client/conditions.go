@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ClusterHasCondition checks whether obj reports the condition conditionType with expectedStatus
+// in status.conditions, malformed conditions are skipped
+// returns:			bool, error
+func ClusterHasCondition(obj *unstructured.Unstructured, conditionType string, expectedStatus string) (bool, error) {
+	conditions, _, err := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	if err != nil {
+		return false, err
+	}
+	for _, v := range conditions {
+		condition, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if condition["type"] == conditionType && condition["status"] == expectedStatus {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// SpokeClusterHasCondition checks whether the managedcluster named name reports the condition
+// conditionType with expectedStatus, e.g. ManagedClusterJoined True
+// returns:			bool, error
+func (c Client) SpokeClusterHasCondition(ctx context.Context, name string, conditionType string, expectedStatus string) (bool, error) {
+	cluster, err := c.getManagedCluster(ctx, name)
+	if err != nil {
+		return false, err
+	}
+	return ClusterHasCondition(cluster, conditionType, expectedStatus)
+}
+
+// getManagedCluster gets the managedcluster named name on the hub
+// returns:			*unstructured.Unstructured, error
+func (c Client) getManagedCluster(ctx context.Context, name string) (*unstructured.Unstructured, error) {
+	gvr := c.servedGVR(ManagedClusterGVR)
+
+	var cluster *unstructured.Unstructured
+	err := c.withRetry(ctx, func() error {
+		opCtx, cancel := c.operationContext(ctx)
+		defer cancel()
+		var err error
+		cluster, err = c.KubernetesClient.Resource(gvr).Get(opCtx, name, v1.GetOptions{})
+		return err
+	})
+	return cluster, err
+}
+
+// WaitForSpokeAvailable polls the managedcluster named name until it reports ManagedClusterConditionAvailable,
+// e.g. while the spoke reboots after a recovery. timeout overrides c.StatusOptions.Timeout when set
+// returns:			error, wrapping ErrSpokeNotAvailable when the spoke isn't available in time
+func (c Client) WaitForSpokeAvailable(ctx context.Context, name string, timeout time.Duration) error {
+	if timeout > 0 {
+		c.StatusOptions.Timeout = timeout
+	}
+
+	return c.pollUntil(ctx, fmt.Errorf("%w: cluster %s", ErrSpokeNotAvailable, name), func(ctx context.Context) (bool, error) {
+		available, err := c.SpokeClusterHasCondition(ctx, name, "ManagedClusterConditionAvailable", "True")
+		if errors.IsNotFound(err) {
+			return false, fmt.Errorf("cluster %s: %w", name, ErrSpokeNotFound)
+		}
+		if isRetryable(err) {
+			return false, err
+		}
+		if err != nil {
+			c.logger().Debugf("couldn't check cluster %s: %s", name, err)
+			return false, nil
+		}
+		return available, nil
+	})
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/disk"
+	"k8s.io/client-go/rest"
+)
+
+// DefaultDiscoveryCacheTTL is the time the discovery data cached on disk is used before being fetched again
+const DefaultDiscoveryCacheTTL = 10 * time.Minute
+
+// DefaultDiscoveryCacheDir returns the directory caching the discovery data when c.DiscoveryCacheDir isn't set
+// returns:			string
+func DefaultDiscoveryCacheDir() string {
+	return filepath.Join(os.TempDir(), "sno-upgrade-recovery", "cache")
+}
+
+// diskDiscoveryClient returns a discovery client caching the discovery data of the hub on disk for c.DiscoveryCacheTTL,
+// shared with the other clients, and processes, connecting to the hub with the same identity. identity is the
+// config holding the host and the credentials of the hub, config is the one the requests are sent with
+// returns:			discovery.CachedDiscoveryInterface, error
+func (c Client) diskDiscoveryClient(identity *rest.Config, config *rest.Config) (discovery.CachedDiscoveryInterface, error) {
+	dir := c.DiscoveryCacheDir
+	if dir == "" {
+		dir = DefaultDiscoveryCacheDir()
+	}
+	ttl := c.DiscoveryCacheTTL
+	if ttl <= 0 {
+		ttl = DefaultDiscoveryCacheTTL
+	}
+
+	key := configIdentity(identity)
+	c.logger().Debugf("caching the discovery data of %s in %s for %s", identity.Host, dir, ttl)
+	return disk.NewCachedDiscoveryClientForConfig(config, filepath.Join(dir, "discovery", key), filepath.Join(dir, "http", key), ttl)
+}
+
+// configIdentity returns a hash of the host, the credentials and the TLS settings of config, so clients connecting to
+// the same hub as different users don't share their discovery data
+// returns:			string
+func configIdentity(config *rest.Config) string {
+	h := sha256.New()
+	for _, field := range []string{
+		config.Host, config.APIPath,
+		config.Username, config.Password, config.BearerToken, config.BearerTokenFile,
+		config.Impersonate.UserName, strings.Join(config.Impersonate.Groups, ","),
+		config.CertFile, config.KeyFile, config.CAFile, config.ServerName,
+		string(config.CertData), string(config.KeyData), string(config.CAData),
+		strconv.FormatBool(config.Insecure),
+	} {
+		h.Write([]byte(field))
+		h.Write([]byte{0})
+	}
+	if config.AuthProvider != nil {
+		fmt.Fprintf(h, "%v", *config.AuthProvider)
+	}
+	if config.ExecProvider != nil {
+		fmt.Fprintf(h, "%v", *config.ExecProvider)
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
@@ -0,0 +1,53 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/rest"
+)
+
+func TestDiskDiscoveryCacheSharedPerIdentity(t *testing.T) {
+	var discoveries int32
+	hub := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api":
+			atomic.AddInt32(&discoveries, 1)
+			_, _ = w.Write([]byte(`{"kind":"APIVersions","versions":["v1"]}`))
+		case "/apis":
+			_, _ = w.Write([]byte(`{"kind":"APIGroupList","groups":[]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer hub.Close()
+	dir := t.TempDir()
+
+	discover := func(token string) {
+		c := newClient([]string{"spoke"}, DefaultBackupPath, "")
+		c.DiscoveryCacheDir = dir
+		c.DiscoveryCacheTTL = time.Hour
+		config := &rest.Config{Host: hub.URL, BearerToken: token, TLSClientConfig: rest.TLSClientConfig{Insecure: true}}
+		if err := c.initClients(config); err != nil {
+			t.Fatal(err)
+		}
+		defer c.Close()
+		if _, err := c.DiscoveryClient.ServerGroups(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	discover("admin")
+	discover("admin")
+	if got := atomic.LoadInt32(&discoveries); got != 1 {
+		t.Errorf("got %d discoveries for the same identity, want 1", got)
+	}
+	discover("viewer")
+	if got := atomic.LoadInt32(&discoveries); got != 2 {
+		t.Errorf("got %d discoveries after a second identity, want 2", got)
+	}
+}
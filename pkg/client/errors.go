@@ -0,0 +1,38 @@
+package client
+
+import "errors"
+
+// ErrViewTimeout is returned when a managedclusterview doesn't report the expected state in the allowed window
+var ErrViewTimeout = errors.New("timed out waiting for the managedclusterview")
+
+// ErrActionFailed is returned when a managedclusteraction reports it couldn't be executed on the spoke
+var ErrActionFailed = errors.New("managedclusteraction failed on the spoke")
+
+// ErrJobFailed is returned as soon as the managedclusterview reports the watched job as failed
+var ErrJobFailed = errors.New("job failed")
+
+// ErrActionTimeout is returned when a managedclusteraction isn't executed on the spoke in the allowed window
+var ErrActionTimeout = errors.New("timed out waiting for the managedclusteraction to be executed")
+
+// ErrSpokeNotFound is returned when the spoke cluster has no managedcluster on the hub
+var ErrSpokeNotFound = errors.New("spoke cluster not found")
+
+// ErrSpokeNotAvailable is returned when the spoke cluster exists but isn't available
+var ErrSpokeNotAvailable = errors.New("spoke cluster not available")
+
+// ErrClusterNamespaceNotFound is returned when the namespace of the spoke doesn't exist on the hub,
+// usually because the spoke isn't fully imported
+var ErrClusterNamespaceNotFound = errors.New("cluster namespace not found on hub")
+
+// ErrConditionsPending is returned when the managedclusterview has accepted the request but reports no condition yet,
+// the status keeps being polled
+var ErrConditionsPending = errors.New("managedclusterview reports no condition yet")
+
+// ErrViewNotCreated is returned when the managedclusterview whose status is checked doesn't exist yet on the hub
+var ErrViewNotCreated = errors.New("managedclusterview not created yet")
+
+// ErrSpokeNotAttempted is returned for the spokes of a multi spoke operation left out because its context was done
+var ErrSpokeNotAttempted = errors.New("spoke not attempted")
+
+// ErrCRDsMissing is returned when the hub doesn't serve a resource the client needs, its CRD not being installed
+var ErrCRDsMissing = errors.New("required resources not served by the hub")
@@ -0,0 +1,331 @@
+package client
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// DefaultConcurrency is the number of spokes processed in parallel when Client.Concurrency isn't set
+const DefaultConcurrency = 5
+
+// SpokeResult holds the outcome of an operation on a single spoke cluster, Retryable tells
+// whether Err is transient and the operation worth retrying, see IsRetryableError
+type SpokeResult struct {
+	Name      string
+	Err       error
+	Retryable bool
+}
+
+// IsRetryableError tells whether err is transient: a throttled, unavailable or timed out API call, or a spoke
+// that didn't answer in time. Invalid names, missing resources or CRDs, denied calls and failed jobs are terminal
+// returns:			bool
+func IsRetryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+	switch {
+	case isRetryable(err), errors.IsTimeout(err), errors.IsServiceUnavailable(err), errors.IsUnexpectedServerError(err):
+		return true
+	case goerrors.Is(err, context.DeadlineExceeded), goerrors.Is(err, ErrViewTimeout), goerrors.Is(err, ErrActionTimeout):
+		return true
+	case goerrors.Is(err, ErrSpokeNotAvailable):
+		return true
+	}
+	return false
+}
+
+// SelectSpokes lists the managedclusters matching labelSelector, e.g. recovery-group=batch1, and replaces c.Spoke
+// with the ones available, the unavailable matches are logged and left out
+// returns:			[]string (selected spokes), error
+func (c *Client) SelectSpokes(ctx context.Context, labelSelector string) ([]string, error) {
+	if _, err := labels.Parse(labelSelector); err != nil {
+		return nil, fmt.Errorf("invalid label selector %q: %w", labelSelector, err)
+	}
+
+	var list *unstructured.UnstructuredList
+	err := c.withRetry(ctx, func() error {
+		opCtx, cancel := c.operationContext(ctx)
+		defer cancel()
+		var err error
+		list, err = c.KubernetesClient.Resource(c.servedGVR(ManagedClusterGVR)).List(opCtx, v1.ListOptions{LabelSelector: labelSelector})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't list the managedclusters matching %q: %w", labelSelector, err)
+	}
+
+	spokes := make([]string, 0, len(list.Items))
+	for i := range list.Items {
+		name := list.Items[i].GetName()
+		available, err := ClusterHasCondition(&list.Items[i], "ManagedClusterConditionAvailable", "True")
+		if err != nil {
+			return nil, fmt.Errorf("couldn't check cluster %s: %w", name, err)
+		}
+		if !available {
+			c.logger().WithFields(log.Fields{"Spoke": name}).Infof("cluster matches %q but is not available, skipping", labelSelector)
+			continue
+		}
+		spokes = append(spokes, name)
+	}
+
+	c.Spoke = spokes
+	return spokes, nil
+}
+
+// LaunchAllSpokes creates the resources rendered from template on every spoke of c.Spoke,
+// processing at most c.Concurrency spokes at a time
+// returns:			per spoke results in c.Spoke order, error summarizing the failed spokes
+func (c Client) LaunchAllSpokes(ctx context.Context, template []ResourceTemplate) ([]SpokeResult, error) {
+	return c.forEachSpoke(ctx, func(ctx context.Context, name string) error {
+		return c.LaunchKubernetesObjects(ctx, name, template)
+	})
+}
+
+// CleanupAll runs Cleanup on every spoke of c.Spoke, processing at most c.Concurrency spokes at a time.
+// A spoke whose namespace is already gone from the hub has nothing left to clean and isn't reported as failed
+// returns:			per spoke results in c.Spoke order, error summarizing the failed spokes
+func (c Client) CleanupAll(ctx context.Context) ([]SpokeResult, error) {
+	return c.forEachSpoke(ctx, func(ctx context.Context, name string) error {
+		err := c.Cleanup(ctx, name)
+		if goerrors.Is(err, ErrClusterNamespaceNotFound) {
+			c.logger().WithFields(log.Fields{"Spoke": name}).Debugf("cluster namespace is gone, nothing to clean up")
+			return nil
+		}
+		return err
+	})
+}
+
+// BackupAll runs Backup on every spoke of c.Spoke, processing at most c.Concurrency spokes at a time.
+// The spokes left out once ctx is done get a RunResult holding the error of their SpokeResult
+// returns:			per spoke run results in c.Spoke order, error summarizing the failed spokes
+func (c Client) BackupAll(ctx context.Context) ([]RunResult, error) {
+	var mu sync.Mutex
+	runs := map[string]RunResult{}
+	results, err := c.forEachSpoke(ctx, func(ctx context.Context, name string) error {
+		run, err := c.Backup(ctx, name)
+		mu.Lock()
+		runs[name] = run
+		mu.Unlock()
+		return err
+	})
+
+	ordered := make([]RunResult, len(results))
+	for i, r := range results {
+		run, ok := runs[r.Name]
+		if !ok {
+			run = RunResult{Cluster: r.Name, Message: r.Err.Error()}
+		}
+		ordered[i] = run
+	}
+	return ordered, err
+}
+
+// forEachSpoke runs fn for every spoke of c.Spoke with a bounded worker pool. Once ctx is done no more spoke
+// is started, the results of the spokes left out wrap ErrSpokeNotAttempted
+// returns:			per spoke results in c.Spoke order, error summarizing the failed spokes
+func (c Client) forEachSpoke(ctx context.Context, fn func(ctx context.Context, name string) error) ([]SpokeResult, error) {
+	concurrency := c.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	results := make([]SpokeResult, len(c.Spoke))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < concurrency && w < len(c.Spoke); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				name := c.Spoke[i]
+				err := fn(ctx, name)
+				results[i] = SpokeResult{Name: name, Err: err, Retryable: IsRetryableError(err)}
+				if results[i].Err != nil {
+					c.logger().WithFields(log.Fields{"Spoke": name, "Retryable": results[i].Retryable}).Errorf("operation failed: %s", results[i].Err)
+				}
+			}
+		}()
+	}
+
+	// stop dispatching once ctx is done, the spokes in flight see the cancellation through their context
+	dispatched := 0
+Dispatch:
+	for ; dispatched < len(c.Spoke) && ctx.Err() == nil; dispatched++ {
+		select {
+		case <-ctx.Done():
+			break Dispatch
+		case indexes <- dispatched:
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	for i := dispatched; i < len(c.Spoke); i++ {
+		results[i] = SpokeResult{Name: c.Spoke[i], Err: fmt.Errorf("%w: %s", ErrSpokeNotAttempted, ctx.Err()), Retryable: true}
+	}
+	if dispatched < len(c.Spoke) {
+		c.logger().Infof("operation cancelled, %d of %d spokes were not attempted", len(c.Spoke)-dispatched, len(c.Spoke))
+	}
+
+	return results, combineSpokeErrors(results)
+}
+
+// combineSpokeErrors merges the errors of the failed spokes into a single one
+// returns:			error, nil when every spoke succeeded
+func combineSpokeErrors(results []SpokeResult) error {
+	var failed []string
+	for _, r := range results {
+		if r.Err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %s", r.Name, r.Err))
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d of %d spokes failed: %s", len(failed), len(results), strings.Join(failed, "; "))
+}
+
+// States of a spoke reported by FleetStatus
+const (
+	StateNoResources = "no-resources"
+	StateJobRunning  = "job-running"
+	StateJobComplete = "job-complete"
+	StateJobFailed   = "job-failed"
+	StateCleaned     = "cleaned"
+)
+
+// PhaseSummary is the state of the backup of a spoke, as seen from the resources left on the hub
+type PhaseSummary struct {
+	// State is one of the State constants
+	State string `json:"state"`
+	// Message is the message of the job when it failed or completed
+	Message string `json:"message,omitempty"`
+	// Actions and Views are the names of the managedclusteractions and managedclusterviews of the client found on the hub
+	Actions []string `json:"actions,omitempty"`
+	Views   []string `json:"views,omitempty"`
+}
+
+// FleetStatus summarizes the state of the backup of every spoke of c.Spoke from the managedclusteractions and
+// managedclusterviews of the client found on the hub, processing at most c.Concurrency spokes at a time
+// returns:			map[string]PhaseSummary (per spoke summaries), error summarizing the spokes that couldn't be read
+func (c Client) FleetStatus(ctx context.Context) (map[string]PhaseSummary, error) {
+	summaries := make(map[string]PhaseSummary, len(c.Spoke))
+	var mu sync.Mutex
+
+	_, err := c.forEachSpoke(ctx, func(ctx context.Context, name string) error {
+		summary, err := c.phaseSummary(ctx, name)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		summaries[name] = summary
+		mu.Unlock()
+		return nil
+	})
+	return summaries, err
+}
+
+// phaseSummary computes the PhaseSummary of clusterName from the resources of the client found on the hub
+// returns:			PhaseSummary, error
+func (c Client) phaseSummary(ctx context.Context, clusterName string) (PhaseSummary, error) {
+	var summary PhaseSummary
+	templates := c.templates()
+
+	actions, err := c.listOwned(ctx, clusterName, MCA)
+	if err != nil {
+		return summary, err
+	}
+	views, err := c.listOwned(ctx, clusterName, MCV)
+	if err != nil {
+		return summary, err
+	}
+
+	jobView := ""
+	if len(templates.ViewCreate) > 0 {
+		jobView = templates.ViewCreate[0].ResourceName
+	}
+	var job *unstructured.Unstructured
+	for i := range views {
+		summary.Views = append(summary.Views, views[i].GetName())
+		if views[i].GetName() == jobView {
+			job = &views[i]
+		}
+	}
+	// Cleanup deletes the actions creating the job before launching the one deleting the namespace, which is
+	// left on the hub, so it only means the spoke was cleaned when no action of a later run was created since
+	deleting, creating := false, false
+	for i := range actions {
+		summary.Actions = append(summary.Actions, actions[i].GetName())
+		if hasTemplate(templates.JobDelete, actions[i].GetName()) {
+			deleting = true
+		}
+		if hasTemplate(templates.ActionCreate, actions[i].GetName()) {
+			creating = true
+		}
+	}
+
+	switch {
+	case deleting && !creating:
+		summary.State = StateCleaned
+	case job != nil:
+		if err := jobFailure(job, clusterName); err != nil {
+			summary.State, summary.Message = StateJobFailed, err.Error()
+			break
+		}
+		result, _, _ := unstructured.NestedMap(job.Object, "status", "result")
+		if res := DecodeJobResult(result); res.Succeeded {
+			summary.State, summary.Message = StateJobComplete, res.Message
+			break
+		}
+		summary.State = StateJobRunning
+	case len(actions) > 0:
+		summary.State = StateJobRunning
+	default:
+		summary.State = StateNoResources
+	}
+	return summary, nil
+}
+
+// hasTemplate tells whether templates holds a template named name
+// returns:			bool
+func hasTemplate(templates []ResourceTemplate, name string) bool {
+	for _, t := range templates {
+		if t.ResourceName == name {
+			return true
+		}
+	}
+	return false
+}
+
+// listOwned lists the managedclusteractions (MCA) or managedclusterviews (MCV) created by this client
+// in the spoke namespace on the hub
+// returns:			[]unstructured.Unstructured, error
+func (c Client) listOwned(ctx context.Context, clusterName string, resourceType string) ([]unstructured.Unstructured, error) {
+	gvr, err := c.resourceGVR(resourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	var list *unstructured.UnstructuredList
+	err = c.withRetry(ctx, func() error {
+		opCtx, cancel := c.operationContext(ctx)
+		defer cancel()
+		var err error
+		list, err = c.KubernetesClient.Resource(gvr).Namespace(clusterName).List(opCtx, v1.ListOptions{LabelSelector: c.OwnedSelector()})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
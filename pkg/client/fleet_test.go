@@ -0,0 +1,27 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestBackupAllCancelled(t *testing.T) {
+	spokes := []string{"spoke-0", "spoke-1", "spoke-2"}
+	c := newClient(spokes, DefaultBackupPath, "")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results, err := c.BackupAll(ctx)
+	if err == nil {
+		t.Fatal("got no error for a cancelled context")
+	}
+	if len(results) != len(spokes) {
+		t.Fatalf("got %d results, want %d", len(results), len(spokes))
+	}
+	for i, res := range results {
+		if res.Cluster != spokes[i] || res.Succeeded || !strings.Contains(res.Message, ErrSpokeNotAttempted.Error()) {
+			t.Errorf("got %+v for %s, want a not attempted result", res, spokes[i])
+		}
+	}
+}
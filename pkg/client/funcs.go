@@ -0,0 +1,62 @@
+package client
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"text/template"
+)
+
+// templateFuncs are the functions available to the templates, named and behaving like their sprig counterparts
+var templateFuncs = template.FuncMap{
+	"default": defaultValue,
+	"quote":   quote,
+	"b64enc":  b64enc,
+	"toJson":  toJSON,
+}
+
+// defaultValue returns given, or def when given is empty, e.g. {{ .ImagePullSecret | default "pull-secret" }}
+// returns:			interface{}
+func defaultValue(def interface{}, given ...interface{}) interface{} {
+	if len(given) == 0 || isEmpty(given[0]) {
+		return def
+	}
+	return given[0]
+}
+
+// isEmpty tells whether v is nil or the zero value of its type
+// returns:			bool
+func isEmpty(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return rv.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return rv.IsNil()
+	default:
+		return rv.IsZero()
+	}
+}
+
+// quote returns v as a double quoted string
+// returns:			string
+func quote(v interface{}) string {
+	return fmt.Sprintf("%q", fmt.Sprint(v))
+}
+
+// b64enc returns the standard base64 encoding of s
+// returns:			string
+func b64enc(s string) string {
+	return base64.StdEncoding.EncodeToString([]byte(s))
+}
+
+// toJSON returns v encoded as JSON, which is also a valid inline YAML value, e.g. - {{ toJson . }}
+// returns:			string, error
+func toJSON(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	return string(b), err
+}
@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// clusterOperatorGVK is the kind of the OpenShift cluster operators of the spoke
+var clusterOperatorGVK = schema.GroupVersionKind{Group: "config.openshift.io", Version: "v1", Kind: "ClusterOperator"}
+
+// HealthOperators are the cluster operators checked by VerifyRestore, the ones running the control plane of the SNO
+var HealthOperators = []string{"etcd", "kube-apiserver", "kube-controller-manager", "kube-scheduler", "openshift-apiserver"}
+
+// OperatorHealth is the status of a cluster operator of the spoke
+type OperatorHealth struct {
+	Name        string `json:"name"`
+	Available   bool   `json:"available"`
+	Degraded    bool   `json:"degraded"`
+	Progressing bool   `json:"progressing"`
+	// Message is the message of the Degraded condition, or of the Available one when the operator isn't available
+	Message string `json:"message,omitempty"`
+}
+
+// HealthSummary is the health of the spoke after a recovery
+type HealthSummary struct {
+	Cluster   string           `json:"cluster"`
+	Operators []OperatorHealth `json:"operators"`
+}
+
+// Healthy tells whether every checked operator is available and not degraded
+// returns:			bool
+func (h HealthSummary) Healthy() bool {
+	for _, op := range h.Operators {
+		if !op.Available || op.Degraded {
+			return false
+		}
+	}
+	return true
+}
+
+// VerifyRestore waits for the spoke to be available again once the recovery utility was run on the node,
+// see backup-image/README.md, then reads the HealthOperators of the spoke through managedclusterviews,
+// which are deleted once read. The spoke is healthy when the summary reports Healthy
+// returns:			HealthSummary, error when the spoke or its operators couldn't be read
+func (c Client) VerifyRestore(ctx context.Context, clusterName string) (HealthSummary, error) {
+	summary := HealthSummary{Cluster: clusterName}
+
+	if err := c.WaitForSpokeAvailable(ctx, clusterName, 0); err != nil {
+		return summary, err
+	}
+
+	for _, name := range HealthOperators {
+		op, err := c.getOperatorHealth(ctx, clusterName, name)
+		if err != nil {
+			return summary, err
+		}
+		if !op.Available || op.Degraded {
+			c.logger().Infof("cluster operator %s of cluster %s isn't healthy: %s", name, clusterName, op.Message)
+		}
+		summary.Operators = append(summary.Operators, op)
+	}
+	return summary, nil
+}
+
+// getOperatorHealth reads the cluster operator named name of the spoke through a managedclusterview
+// returns:			OperatorHealth, error
+func (c Client) getOperatorHealth(ctx context.Context, clusterName string, name string) (OperatorHealth, error) {
+	op := OperatorHealth{Name: name}
+
+	viewName, err := c.CreateView(ctx, clusterName, clusterOperatorGVK, name, "")
+	if err != nil {
+		return op, fmt.Errorf("couldn't create the clusteroperator %s view in the %s cluster err: %w", name, clusterName, err)
+	}
+	defer func() {
+		if err := c.DeleteView(context.Background(), clusterName, viewName); err != nil {
+			c.logger().Errorf("couldn't delete the clusteroperator %s view in the %s cluster err: %s", name, clusterName, err)
+		}
+	}()
+
+	var result map[string]interface{}
+	err = c.pollUntil(ctx, fmt.Errorf("%w: clusteroperator %s of cluster %s", ErrViewTimeout, name, clusterName), func(ctx context.Context) (bool, error) {
+		res, err := c.GetViewResult(ctx, clusterName, viewName)
+		if isRetryable(err) {
+			return false, err
+		}
+		if err != nil {
+			c.logger().Debugf("couldn't get the clusteroperator %s view of cluster %s: %s", name, clusterName, err)
+			return false, nil
+		}
+		result = res.Result
+		return len(result) > 0, nil
+	})
+	if err != nil {
+		return op, err
+	}
+
+	conditions, _, err := unstructured.NestedSlice(result, "status", "conditions")
+	if err != nil {
+		return op, err
+	}
+	for _, condition := range conditions {
+		cond, ok := condition.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		isTrue := cond["status"] == "True"
+		switch cond["type"] {
+		case "Available":
+			op.Available = isTrue
+			if !isTrue && op.Message == "" {
+				op.Message = conditionMessage(cond)
+			}
+		case "Degraded":
+			op.Degraded = isTrue
+			if isTrue {
+				op.Message = conditionMessage(cond)
+			}
+		case "Progressing":
+			op.Progressing = isTrue
+		}
+	}
+	return op, nil
+}
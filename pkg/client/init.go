@@ -3,19 +3,27 @@ package client
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	goerrors "errors"
 	"fmt"
 	"math/rand"
+	"net"
+	"net/http"
+	"path"
 	"strings"
 	"time"
 
-	"text/template"
-
 	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer/yaml"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/discovery"
 	memory "k8s.io/client-go/discovery/cached"
 	"k8s.io/client-go/dynamic"
@@ -38,19 +46,161 @@ var (
 	Complete     = "completed"
 )
 
+// ManagedClusterActionGVR, ManagedClusterViewGVR and ManagedClusterGVR are the ACM resources used on the hub
+var (
+	ManagedClusterActionGVR = schema.GroupVersionResource{Group: "action.open-cluster-management.io", Version: "v1beta1", Resource: MCA}
+	ManagedClusterViewGVR   = schema.GroupVersionResource{Group: "view.open-cluster-management.io", Version: "v1beta1", Resource: MCV}
+	ManagedClusterGVR       = schema.GroupVersionResource{Group: "cluster.open-cluster-management.io", Version: "v1", Resource: "managedclusters"}
+)
+
+// DefaultNamespace is the namespace created on the spoke to run the backup job
+const DefaultNamespace = "backupresource"
+
+// DefaultManagedBy is the app.kubernetes.io/managed-by label value stamped on the created resources
+const DefaultManagedBy = "sno-upgrade-recovery"
+
+// ManagedByLabel and RunIDLabel are the labels stamped on every resource created from the templates
+const (
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+	RunIDLabel     = "sno-upgrade-recovery/run-id"
+)
+
+// DefaultServiceAccountName and DefaultClusterRoleName are the service account running the jobs on the spoke
+// and the cluster role bound to it
+const (
+	DefaultServiceAccountName = "backupresource"
+	DefaultClusterRoleName    = "cluster-admin"
+)
+
+// DefaultCPURequest and DefaultMemRequest are the resources requested by the jobs on the spoke,
+// no limit is set by default so the backup isn't throttled
+const (
+	DefaultCPURequest = "100m"
+	DefaultMemRequest = "256Mi"
+)
+
+// ContentHashAnnotation holds the hash of the rendered template on the resources applied when Apply is set,
+// a resource whose live hash matches is left untouched
+const ContentHashAnnotation = "sno-upgrade-recovery/content-hash"
+
+// DefaultFieldManager is the field manager of the server-side applies done when Apply is set,
+// unless Client.FieldManager overrides it
+const DefaultFieldManager = "sno-upgrade-recovery"
+
+// DefaultBackupPath is the path of the recovery partition of the spokes used by NewWithOptions
+// unless WithBackupPath overrides it
+const DefaultBackupPath = "/var/recovery"
+
+// DefaultImage is the image running the backup job on the spoke
+const DefaultImage = "2620-52-0-1302--1db3.sslip.io:5000/olm/openshift-ai-image-backup:latest"
+
 // Client provides a k8s dynamic client
 type Client struct {
-	Spoke            []string
-	BackupPath       string
-	KubeconfigPath   string
-	KubernetesClient dynamic.Interface
+	Spoke              []string
+	BackupPath         string
+	BackupPaths        map[string]string
+	KubeconfigPath     string
+	Config             *rest.Config
+	CAData             []byte
+	Insecure           bool
+	QPS                float32
+	Burst              int
+	Namespace          string
+	Image              string
+	ImagePullSecret    string
+	ServiceAccountName string
+	ClusterRoleName    string
+	CPURequest         string
+	MemRequest         string
+	CPULimit           string
+	MemLimit           string
+	ScanInterval       int
+	TTLSeconds         int
+	Volumes            []corev1.Volume
+	VolumeMounts       []corev1.VolumeMount
+	Command            []string
+	Args               []string
+	NodeSelector       map[string]string
+	Tolerations        []corev1.Toleration
+	ManagedBy          string
+	RunID              string
+	OwnerReferences    []v1.OwnerReference
+	OnPhase            func(phase string, cluster string)
+	KubernetesClient   dynamic.Interface
+	DiscoveryClient    discovery.CachedDiscoveryInterface
+	DiscoveryCacheDir  string
+	DiscoveryCacheTTL  time.Duration
+	RESTMapper         *restmapper.DeferredDiscoveryRESTMapper
+	transport          http.RoundTripper
+	StatusOptions      CheckStatusOptions
+	RetryOptions       RetryOptions
+	RetryPolicy        RetryPolicy
+	OperationTimeout   time.Duration
+	ConnectTimeout     time.Duration
+	Concurrency        int
+	Logger             log.FieldLogger
+	DryRun             bool
+	ServerDryRun       bool
+	Apply              bool
+	FieldManager       string
+	ForceApply         bool
+	ValidateTemplates  bool
+	CleanupAfterBackup bool
+	KeepViews          bool
+	Templates          TemplateSet
+}
+
+// CheckStatusOptions controls how long and how often JobStatus polls the managedclusterview
+type CheckStatusOptions struct {
+	// Timeout is the total time to wait for the view to report the expected status
+	Timeout time.Duration
+	// Interval is the delay between two consecutive polls of the view
+	Interval time.Duration
+	// Jitter spreads the polls of concurrent runs, every interval is randomly moved by up to this
+	// fraction of Interval, e.g. 0.2 for ±20%. Zero polls on a fixed cadence
+	Jitter float64
 }
 
-// TemplateData provides template rendering data
+// DefaultCheckStatusOptions returns the polling options built from TimeOut and TimeInterval
+// returns:			CheckStatusOptions
+func DefaultCheckStatusOptions() CheckStatusOptions {
+	return CheckStatusOptions{
+		Timeout:  time.Second * time.Duration(TimeOut),
+		Interval: time.Second * time.Duration(TimeInterval),
+		Jitter:   DefaultPollJitter,
+	}
+}
+
+// TemplateData provides template rendering data. ScanInterval is the refresh interval of the views in seconds
+// and TTLSeconds the time the finished jobs are kept on the spoke, both are left to their defaults when 0.
+// A TTLSeconds shorter than the status polling lets the job vanish before its completion is seen.
+// Volumes and VolumeMounts are added to the jobs next to the hostPath of the spoke root mounted on /host.
+// Command and Args replace the command and the launchBackup arguments of the backup job when set.
+// NodeSelector and Tolerations constrain where the jobs are scheduled, e.g. on a tainted control-plane node
 type TemplateData struct {
-	ResourceName string
-	ClusterName  string
-	RecoveryPath string
+	ResourceName       string
+	ClusterName        string
+	RecoveryPath       string
+	Namespace          string
+	Image              string
+	ImagePullSecret    string
+	ServiceAccountName string
+	ClusterRoleName    string
+	CPURequest         string
+	MemRequest         string
+	CPULimit           string
+	MemLimit           string
+	ScanInterval       int
+	TTLSeconds         int
+	Volumes            []corev1.Volume
+	VolumeMounts       []corev1.VolumeMount
+	Command            []string
+	Args               []string
+	NodeSelector       map[string]string
+	Tolerations        []corev1.Toleration
+	ManagedBy          string
+	RunID              string
+	Scope              ViewScope
 }
 
 // ResourceTemplate define a resource template structure
@@ -78,305 +228,1159 @@ var JobDeleteTemplates = []ResourceTemplate{
 	{"backup-delete-ns", mngClusterActDeleteNS},
 }
 
+// TemplateSet holds the templates used by a client, so they can be overridden per client
+type TemplateSet struct {
+	ActionCreate []ResourceTemplate
+	ViewCreate   []ResourceTemplate
+	JobDelete    []ResourceTemplate
+}
+
+// DefaultTemplates returns a copy of the package level templates
+// returns:			TemplateSet
+func DefaultTemplates() TemplateSet {
+	return TemplateSet{
+		ActionCreate: append([]ResourceTemplate(nil), ActionCreateTemplates...),
+		ViewCreate:   append([]ResourceTemplate(nil), ViewCreateTemplates...),
+		JobDelete:    append([]ResourceTemplate(nil), JobDeleteTemplates...),
+	}
+}
+
+// templates returns the templates of the client, using the defaults for the sets left empty
+// returns:			TemplateSet
+func (c Client) templates() TemplateSet {
+	t := c.Templates
+	defaults := DefaultTemplates()
+	if len(t.ActionCreate) == 0 {
+		t.ActionCreate = defaults.ActionCreate
+	}
+	if len(t.ViewCreate) == 0 {
+		t.ViewCreate = defaults.ViewCreate
+	}
+	if len(t.JobDelete) == 0 {
+		t.JobDelete = defaults.JobDelete
+	}
+	return t
+}
+
 // New creates a new instance of k8s client
 // returns:			client, error
 func New(Spoke []string, BackupPath string, KubeconfigPath string) (Client, error) {
+	return NewWithOptions(Spoke, WithBackupPath(BackupPath), WithKubeconfigPath(KubeconfigPath))
+}
+
+// NewWithOptions creates a new instance of k8s client holding the default settings overridden by opts,
+// which are applied in order before the settings are validated and the hub is connected
+// returns:			client, error
+func NewWithOptions(Spoke []string, opts ...Option) (Client, error) {
+	c := newClient(Spoke, DefaultBackupPath, "")
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	if err := c.Validate(); err != nil {
+		c.logger().Error(err)
+		return c, err
+	}
+
+	// generate config from file or from the in-cluster environment
+	config, err := c.GetConfig()
+	if err != nil {
+		c.logger().Error(err)
+		return c, err
+	}
+
+	if err = c.initClients(config); err != nil {
+		c.logger().Error(err)
+		return c, err
+	}
+
+	if err = c.checkConnectivity(config); err != nil {
+		c.logger().Error(err)
+		return c, err
+	}
+
+	return c, nil
+}
+
+// NewWithConfig creates a new instance of k8s client from an already built rest config,
+// kubeconfig content can be turned into one with clientcmd.RESTConfigFromKubeConfig
+// returns:			client, error
+func NewWithConfig(Spoke []string, BackupPath string, config *rest.Config) (Client, error) {
+	c := newClient(Spoke, BackupPath, "")
+
+	if err := c.Validate(); err != nil {
+		c.logger().Error(err)
+		return c, err
+	}
+
+	if config == nil {
+		err := fmt.Errorf("the rest config must not be nil")
+		c.logger().Error(err)
+		return c, err
+	}
+
+	if err := c.initClients(config); err != nil {
+		c.logger().Error(err)
+		return c, err
+	}
+
+	return c, nil
+}
+
+// NewWithClients creates a new instance of k8s client from already built dynamic and discovery clients,
+// so tests can pass dynamicfake.NewSimpleDynamicClient and a fake discovery client without any cluster.
+// The discovery client is cached in memory unless it's already a cached one, e.g. a disk backed one
+// built with k8s.io/client-go/discovery/cached/disk to keep the discovery across processes
+// returns:			client, error
+func NewWithClients(Spoke []string, BackupPath string, kubernetesClient dynamic.Interface, discoveryClient discovery.DiscoveryInterface) (Client, error) {
+	c := newClient(Spoke, BackupPath, "")
+
+	if err := c.Validate(); err != nil {
+		c.logger().Error(err)
+		return c, err
+	}
+
+	if kubernetesClient == nil || discoveryClient == nil {
+		err := fmt.Errorf("the dynamic and discovery clients must not be nil")
+		c.logger().Error(err)
+		return c, err
+	}
+
+	c.setClients(kubernetesClient, discoveryClient)
+	return c, nil
+}
+
+// newClient returns a client holding the default settings, without any kubernetes client
+// returns:			client
+func newClient(Spoke []string, BackupPath string, KubeconfigPath string) Client {
 	rand.Seed(time.Now().UnixNano())
-	c := Client{Spoke, BackupPath, KubeconfigPath, nil}
+	return Client{
+		Spoke:              Spoke,
+		BackupPath:         BackupPath,
+		KubeconfigPath:     KubeconfigPath,
+		Namespace:          DefaultNamespace,
+		Image:              DefaultImage,
+		ServiceAccountName: DefaultServiceAccountName,
+		ClusterRoleName:    DefaultClusterRoleName,
+		CPURequest:         DefaultCPURequest,
+		MemRequest:         DefaultMemRequest,
+		ManagedBy:          DefaultManagedBy,
+		FieldManager:       DefaultFieldManager,
+		StatusOptions:      DefaultCheckStatusOptions(),
+		RetryPolicy:        DefaultRetryPolicy(),
+		OperationTimeout:   DefaultOperationTimeout,
+		ConnectTimeout:     DefaultConnectTimeout,
+		Concurrency:        DefaultConcurrency,
+		QPS:                rest.DefaultQPS,
+		Burst:              rest.DefaultBurst,
+		Logger:             log.StandardLogger(),
+		Templates:          DefaultTemplates(),
+		ValidateTemplates:  true,
+		CleanupAfterBackup: true,
+	}
+}
 
-	var clientset dynamic.Interface
+// managedBy returns the managed-by label value of the client, defaulting to DefaultManagedBy
+// returns:			string
+func (c Client) managedBy() string {
+	if c.ManagedBy == "" {
+		return DefaultManagedBy
+	}
+	return c.ManagedBy
+}
 
-	if KubeconfigPath != "" {
-		// generate config from file
-		config, err := c.GetConfig()
-		if err != nil {
-			log.Error(err)
-			return c, err
-		}
-		// now try to connect to cluster
-		clientset, err = dynamic.NewForConfig(config)
-		if err != nil {
-			log.Error(err)
-			return c, err
+// SetOwner makes owner, of kind gvk, the controller of the managedclusteractions and managedclusterviews created
+// by the client, so they are garbage collected with it. The owner must live in the namespace of the spoke on the hub
+// or be cluster scoped
+func (c *Client) SetOwner(owner v1.Object, gvk schema.GroupVersionKind) {
+	c.OwnerReferences = []v1.OwnerReference{*v1.NewControllerRef(owner, gvk)}
+}
 
+// NewRunID returns a random identifier of a backup run, set as RunID to label the created
+// resources and correlate the logs of the run
+// returns:			string
+func NewRunID() string {
+	return fmt.Sprintf("%08x", rand.Uint32())
+}
+
+// withRun returns a copy of the client with a fresh RunID, unless one is already set,
+// logging every line with the RunID field
+// returns:			Client
+func (c Client) withRun() Client {
+	if c.RunID == "" {
+		c.RunID = NewRunID()
+	}
+	c.Logger = c.logger().WithField("RunID", c.RunID)
+	return c
+}
+
+// OwnedSelector returns the label selector matching the resources created by this client,
+// restricted to the current run when RunID is set
+// returns:			string
+func (c Client) OwnedSelector() string {
+	selector := ManagedByLabel + "=" + c.managedBy()
+	if c.RunID != "" {
+		selector += "," + RunIDLabel + "=" + c.RunID
+	}
+	return selector
+}
+
+// logger returns the logger of the client, defaulting to the logrus standard logger
+// returns:			log.FieldLogger
+func (c Client) logger() log.FieldLogger {
+	if c.Logger == nil {
+		return log.StandardLogger()
+	}
+	return c.Logger
+}
+
+// Validate verifies the client settings before any kubernetes object is created
+// returns:			error
+func (c Client) Validate() error {
+	if c.BackupPath == "" {
+		return fmt.Errorf("the backup path must not be empty")
+	}
+	if !path.IsAbs(c.BackupPath) {
+		return fmt.Errorf("the backup path %q must be an absolute path", c.BackupPath)
+	}
+	for _, cluster := range c.Spoke {
+		if err := ValidateClusterName(cluster); err != nil {
+			return err
 		}
-	} else {
-		config, err := rest.InClusterConfig()
-		if err != nil {
-			log.Error(err)
-			return c, err
+	}
+	for cluster, p := range c.BackupPaths {
+		if !path.IsAbs(p) {
+			return fmt.Errorf("the backup path %q of cluster %s must be an absolute path", p, cluster)
 		}
+	}
+	return nil
+}
 
-		// now try to connect to cluster
-		clientset, err = dynamic.NewForConfig(config)
-		if err != nil {
-			log.Error(err)
-			return c, err
-		}
+// ValidateClusterName verifies name is a valid spoke cluster name, i.e. a RFC 1123 label usable as its namespace on the hub
+// returns:			error
+func ValidateClusterName(name string) error {
+	if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+		return fmt.Errorf("invalid cluster name %q: %s", name, strings.Join(errs, ", "))
 	}
-	c.KubernetesClient = clientset
+	return nil
+}
 
-	return c, nil
+// backupPath returns the backup path of clusterName, falling back to BackupPath
+// when BackupPaths has no override for it
+// returns:			string
+func (c Client) backupPath(clusterName string) string {
+	if p, ok := c.BackupPaths[clusterName]; ok && p != "" {
+		return p
+	}
+	return c.BackupPath
 }
 
-// SpokeClusterExists verifies if a provided spoke cluster do exist or not
-// returns:			bool
-func (c Client) SpokeClusterExists(name string) bool {
+// initClients builds the transport, the dynamic client, the cached discovery client and the RESTMapper from config,
+// the connection overrides of the client are applied to a copy of config. When c.DiscoveryCacheDir or c.DiscoveryCacheTTL
+// is set the discovery data is cached on disk, shared with the other clients of the hub and fetched again once older than the TTL
+// returns:			error
+func (c *Client) initClients(config *rest.Config) error {
+	identity := c.applyConfigOverrides(config)
+
+	// client-go doesn't cache the transports of configs with a custom Dial, so the clients share
+	// one built here, which Close can then release
+	transport, err := rest.TransportFor(identity)
+	if err != nil {
+		return err
+	}
+	clientConfig := transportConfig(identity, transport)
+
+	// now try to connect to cluster
+	clientset, err := dynamic.NewForConfig(clientConfig)
+	if err != nil {
+		return err
+	}
+
+	var discoveryClient discovery.DiscoveryInterface
+	if c.DiscoveryCacheDir != "" || c.DiscoveryCacheTTL > 0 {
+		discoveryClient, err = c.diskDiscoveryClient(identity, clientConfig)
+	} else {
+		discoveryClient, err = discovery.NewDiscoveryClientForConfig(clientConfig)
+	}
+	if err != nil {
+		return err
+	}
+
+	c.Config = config
+	c.transport = transport
+	c.setClients(clientset, discoveryClient)
+	return nil
+}
 
-	// using client, get if spoke cluster with given name exists
-	gvr := schema.GroupVersionResource{
-		Group:    "cluster.open-cluster-management.io",
-		Version:  "v1",
-		Resource: "managedclusters",
+// applyConfigOverrides returns a copy of config with the CAData, Insecure, QPS, Burst and ConnectTimeout settings of the client
+// returns:			*rest.Config
+func (c Client) applyConfigOverrides(config *rest.Config) *rest.Config {
+	clientConfig := rest.CopyConfig(config)
+	if c.QPS > 0 {
+		clientConfig.QPS = c.QPS
+	}
+	if c.Burst > 0 {
+		clientConfig.Burst = c.Burst
+	}
+	if len(c.CAData) > 0 {
+		clientConfig.TLSClientConfig.CAData = c.CAData
+		clientConfig.TLSClientConfig.CAFile = ""
+	}
+	if c.ConnectTimeout > 0 {
+		// only the dial is bounded, a request timeout would also cut the watches
+		clientConfig.Dial = (&net.Dialer{Timeout: c.ConnectTimeout, KeepAlive: 30 * time.Second}).DialContext
+	}
+	if c.Insecure {
+		// client-go refuses a root CA along with the insecure flag
+		clientConfig.TLSClientConfig.Insecure = true
+		clientConfig.TLSClientConfig.CAData = nil
+		clientConfig.TLSClientConfig.CAFile = ""
+	}
+	return clientConfig
+}
+
+// transportConfig returns a copy of config sending its requests through transport, which already carries
+// the TLS settings, the credentials and the dial of config
+// returns:			*rest.Config
+func transportConfig(config *rest.Config, transport http.RoundTripper) *rest.Config {
+	clientConfig := rest.AnonymousClientConfig(config)
+	clientConfig.TLSClientConfig = rest.TLSClientConfig{}
+	clientConfig.Dial = nil
+	clientConfig.Proxy = nil
+	clientConfig.Transport = transport
+	return clientConfig
+}
+
+// Reconnect rebuilds the kubernetes clients from the client config, so the connection settings
+// changed after New, like CAData, Insecure, QPS or Burst, are taken into account, and checks the
+// hub is reachable with them
+// returns:			error
+func (c *Client) Reconnect() error {
+	config, err := c.GetConfig()
+	if err != nil {
+		return err
 	}
+	if err := c.initClients(config); err != nil {
+		return err
+	}
+	return c.checkConnectivity(config)
+}
 
-	log.WithFields(log.Fields{"SpokeStatus": "Checking"}).Debugf("Checking if the Spoke cluster: %s exist...", name)
-	foundSpokeCluster, err := c.KubernetesClient.Resource(gvr).Get(context.Background(), name, v1.GetOptions{})
+// setClients sets the dynamic client, the cached discovery client and the RESTMapper built on top of it
+func (c *Client) setClients(kubernetesClient dynamic.Interface, discoveryClient discovery.DiscoveryInterface) {
+	cached, ok := discoveryClient.(discovery.CachedDiscoveryInterface)
+	if !ok {
+		cached = memory.NewMemCacheClient(discoveryClient)
+	}
+	c.KubernetesClient = kubernetesClient
+	c.DiscoveryClient = cached
+	c.RESTMapper = restmapper.NewDeferredDiscoveryRESTMapper(cached)
+}
 
+// SpokeClusterExists verifies if a provided spoke cluster do exist and is available
+// returns:			bool
+func (c Client) SpokeClusterExists(ctx context.Context, name string) bool {
+	available, _, err := c.SpokeClusterStatus(ctx, name)
 	if err != nil {
-		log.Error(err)
+		c.logger().Error(err)
 		return false
 	}
+	return available
+}
 
-	// transform to typed
-	if foundSpokeCluster != nil {
-		status, _, err := unstructured.NestedMap(foundSpokeCluster.Object, "status")
-		if err != nil {
-			log.Error(err)
-			return false
-		}
-		if status != nil {
-			if conditions, ok := status["conditions"]; ok {
-				// check for condition
-				for _, v := range conditions.([]interface{}) {
-					key := v.(map[string]interface{})["type"]
-					if key == "ManagedClusterConditionAvailable" {
-						val := v.(map[string]interface{})["status"]
-						if val == "True" {
-							// exists and is available
-							return true
-						}
-					}
-				}
-			}
-		}
+// SpokeClusterStatus verifies if a provided spoke cluster do exist and is available,
+// a missing cluster is reported through found and isn't an error
+// returns:			available bool, found bool, error
+func (c Client) SpokeClusterStatus(ctx context.Context, name string) (bool, bool, error) {
+
+	c.logger().WithFields(log.Fields{"SpokeStatus": "Checking"}).Debugf("Checking if the Spoke cluster: %s exist...", name)
+	foundSpokeCluster, err := c.getManagedCluster(ctx, name)
+	if errors.IsNotFound(err) {
+		c.logger().WithFields(log.Fields{"SpokeStatus": "NotFound"}).Infof("Spoke cluster: %s does not exist", name)
+		return false, false, nil
+	}
+	if err != nil {
+		return false, false, err
+	}
+
+	available, err := ClusterHasCondition(foundSpokeCluster, "ManagedClusterConditionAvailable", "True")
+	if err != nil {
+		return false, true, err
+	}
+	if available {
+		// exists and is available
+		c.logger().WithFields(log.Fields{"SpokeStatus": "Found"}).Infof("Spoke cluster: %s exists and is available", name)
+		return true, true, nil
+	}
+
+	c.logger().WithFields(log.Fields{"SpokeStatus": "NotAvailable"}).Infof("Spoke cluster: %s exists but is not available", name)
+	return false, true, nil
+}
+
+// LoadKubeconfig loads the rest config from the kubeconfig at path, checking it has a usable current context
+// returns:			*rest.Config, error naming the kubeconfig and its context
+func LoadKubeconfig(path string) (*rest.Config, error) {
+	kubeconfig, err := clientcmd.LoadFromFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't load the kubeconfig %s: %w", path, err)
+	}
+	if kubeconfig.CurrentContext == "" {
+		return nil, fmt.Errorf("the kubeconfig %s has no current-context", path)
+	}
+	if _, ok := kubeconfig.Contexts[kubeconfig.CurrentContext]; !ok {
+		return nil, fmt.Errorf("the current-context %q of the kubeconfig %s doesn't exist", kubeconfig.CurrentContext, path)
+	}
 
+	config, err := clientcmd.NewDefaultClientConfig(*kubeconfig, &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("the context %q of the kubeconfig %s is unusable: %w", kubeconfig.CurrentContext, path, err)
 	}
-	log.WithFields(log.Fields{"SpokeStatus": "Found"}).Infof("Spoke cluster: %s exists", c.Spoke)
-	return false
+	return config, nil
 }
 
-// GetConfig verifies providedkubeconfig
+// checkConnectivity asks the server version of the hub, so an unreachable hub fails at startup
+// rather than on the first call
+// returns:			error naming the hub and the kubeconfig
+func (c Client) checkConnectivity(config *rest.Config) error {
+	pingConfig := c.applyConfigOverrides(config)
+	if c.transport != nil {
+		pingConfig = transportConfig(pingConfig, c.transport)
+	}
+	pingConfig.Timeout = c.ConnectTimeout
+	if pingConfig.Timeout <= 0 {
+		pingConfig.Timeout = DefaultConnectTimeout
+	}
+
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(pingConfig)
+	if err != nil {
+		return err
+	}
+	if _, err := discoveryClient.ServerVersion(); err != nil {
+		source := c.KubeconfigPath
+		if source == "" {
+			source = "in-cluster config"
+		}
+		return fmt.Errorf("couldn't reach the hub %s from %s within %s: %w", config.Host, source, pingConfig.Timeout, err)
+	}
+	return nil
+}
+
+// GetConfig returns the config cached by New, or builds it from the provided kubeconfig,
+// or from the in-cluster environment when no kubeconfig is provided
 // returns:			*rest.Config, error
 func (c Client) GetConfig() (*rest.Config, error) {
-	config, err := clientcmd.BuildConfigFromFlags("", c.KubeconfigPath)
+	if c.Config != nil {
+		return c.Config, nil
+	}
+
+	var config *rest.Config
+	var err error
+	if c.KubeconfigPath != "" {
+		config, err = LoadKubeconfig(c.KubeconfigPath)
+	} else {
+		config, err = rest.InClusterConfig()
+	}
 	if err != nil {
-		log.Error(err)
+		c.logger().Error(err)
 		return nil, err
 	}
 	return config, nil
 }
 
-// LaunchKubernetesObjects creates managedclusteraction and managedclusterview resources from template
+// LaunchKubernetesObjects creates managedclusteraction and managedclusterview resources from template,
+// stopping at the first template that fails.
+// When c.DryRun is set the resources are rendered, mapped and logged but not created, when c.ServerDryRun
+// is set they are sent to the hub, which validates them and runs its admission webhooks without persisting them
 // returns:			error
-func (c Client) LaunchKubernetesObjects(clusterName string, template []ResourceTemplate) error {
-	config, err := c.GetConfig()
-	if err != nil {
-		log.Error(err)
-		return err
-	}
+func (c Client) LaunchKubernetesObjects(ctx context.Context, clusterName string, template []ResourceTemplate) error {
+	_, err := c.launchKubernetesObjects(ctx, clusterName, template, false)
+	return err
+}
+
+// LaunchKubernetesObjectsResult creates the resources of template like LaunchKubernetesObjects and returns them
+// as created on the hub, in the template order, so their UID and resourceVersion can be recorded. The entry of a
+// resource that already existed or wasn't created because of c.DryRun is nil
+// returns:			[]*unstructured.Unstructured, error
+func (c Client) LaunchKubernetesObjectsResult(ctx context.Context, clusterName string, template []ResourceTemplate) ([]*unstructured.Unstructured, error) {
+	return c.launchKubernetesObjects(ctx, clusterName, template, false)
+}
+
+// LaunchAllKubernetesObjects creates the resources of template like LaunchKubernetesObjects, but attempts
+// every template and reports the ones that failed together
+// returns:			error, a TemplateErrors listing the failed templates
+func (c Client) LaunchAllKubernetesObjects(ctx context.Context, clusterName string, template []ResourceTemplate) error {
+	_, err := c.launchKubernetesObjects(ctx, clusterName, template, true)
+	return err
+}
 
-	newdata := TemplateData{
-		ResourceName: "",
-		ClusterName:  clusterName,
-		RecoveryPath: c.BackupPath,
+// TemplateError is the error of a single template of a launch
+type TemplateError struct {
+	ResourceName string
+	Err          error
+}
+
+// TemplateErrors gathers the errors of the templates that failed in LaunchAllKubernetesObjects
+type TemplateErrors []TemplateError
+
+func (e TemplateErrors) Error() string {
+	failed := make([]string, 0, len(e))
+	for _, t := range e {
+		failed = append(failed, fmt.Sprintf("%s: %s", t.ResourceName, t.Err))
 	}
+	return fmt.Sprintf("%d templates failed: %s", len(e), strings.Join(failed, "; "))
+}
 
-	for _, item := range template {
-		obj := &unstructured.Unstructured{}
-		newdata.ResourceName = item.ResourceName
+// launchKubernetesObjects creates the resources of template, returning on the first error
+// unless collect is set, in which case every template is attempted
+// returns:			[]*unstructured.Unstructured (created resources), error
+func (c Client) launchKubernetesObjects(ctx context.Context, clusterName string, template []ResourceTemplate, collect bool) ([]*unstructured.Unstructured, error) {
+	if err := ValidateClusterName(clusterName); err != nil {
+		return nil, err
+	}
+	if c.RESTMapper == nil {
+		return nil, fmt.Errorf("the client has no RESTMapper, it must be created with New")
+	}
 
-		log.Debug(strings.Repeat("-", 60))
-		log.WithFields(log.Fields{"LaunchKubernetesObjects": "Launching"}).Debugf("Creating kubernetes object: [ %s ]", item.ResourceName)
-		log.Debug(strings.Repeat("-", 60))
+	data := c.templateData(clusterName)
 
-		log.Debugf("rendering resource: %s, data passed: %s for cluster: %s", item.ResourceName, newdata, clusterName)
-		w, err := c.RenderYamlTemplate(item.ResourceName, item.Template, newdata)
-		if err != nil && !errors.IsAlreadyExists(err) {
-			return err
+	created := make([]*unstructured.Unstructured, 0, len(template))
+	var errs TemplateErrors
+	for _, item := range template {
+		if err := ctx.Err(); err != nil {
+			return created, err
 		}
-		log.Debug("Retreiving GVK....")
-		// decode YAML into unstructured.Unstructured
-		dec := yaml.NewDecodingSerializer(unstructured.UnstructuredJSONScheme)
-		_, gvk, err := dec.Decode(w.Bytes(), nil, obj)
+		data.ResourceName = item.ResourceName
+		obj, err := c.launchKubernetesObject(ctx, clusterName, item, data)
+		created = append(created, obj)
 		if err != nil {
-			return err
+			if !collect {
+				return created, err
+			}
+			errs = append(errs, TemplateError{ResourceName: item.ResourceName, Err: err})
 		}
+	}
+	if len(errs) > 0 {
+		return created, errs
+	}
+	return created, nil
+}
+
+// templateData returns the data rendered in the templates of clusterName
+// returns:			TemplateData
+func (c Client) templateData(clusterName string) TemplateData {
+	data := TemplateData{
+		ResourceName:       "",
+		ClusterName:        clusterName,
+		RecoveryPath:       c.backupPath(clusterName),
+		Namespace:          c.targetNamespace(),
+		Image:              c.Image,
+		ImagePullSecret:    c.ImagePullSecret,
+		ServiceAccountName: c.ServiceAccountName,
+		ClusterRoleName:    c.ClusterRoleName,
+		CPURequest:         c.CPURequest,
+		MemRequest:         c.MemRequest,
+		CPULimit:           c.CPULimit,
+		MemLimit:           c.MemLimit,
+		ScanInterval:       c.ScanInterval,
+		TTLSeconds:         c.TTLSeconds,
+		Volumes:            c.Volumes,
+		VolumeMounts:       c.VolumeMounts,
+		Command:            c.Command,
+		Args:               c.Args,
+		NodeSelector:       c.NodeSelector,
+		Tolerations:        c.Tolerations,
+		ManagedBy:          c.managedBy(),
+		RunID:              c.RunID,
+	}
+	if data.Image == "" {
+		data.Image = DefaultImage
+	}
+	if data.ServiceAccountName == "" {
+		data.ServiceAccountName = DefaultServiceAccountName
+	}
+	if data.ClusterRoleName == "" {
+		data.ClusterRoleName = DefaultClusterRoleName
+	}
+	return data
+}
 
-		log.Debugf("Retrieved GVK: %s", gvk)
+// launchKubernetesObject renders item with data, maps it with the RESTMapper and creates it,
+// a resource already created by a previous run is skipped. When c.Apply is set the resource is
+// server-side applied instead
+// returns:			*unstructured.Unstructured (created resource, nil when skipped), error
+func (c Client) launchKubernetesObject(ctx context.Context, clusterName string, item ResourceTemplate, data TemplateData) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{}
 
-		log.Debug("Mapping gvk to gvr with discovery client....")
+	c.logger().Debug(strings.Repeat("-", 60))
+	c.logger().WithFields(log.Fields{"LaunchKubernetesObjects": "Launching"}).Debugf("Creating kubernetes object: [ %s ]", item.ResourceName)
+	c.logger().Debug(strings.Repeat("-", 60))
 
-		// Map GVK to GVR with discovery client
-		discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
-		if err != nil {
-			return err
+	c.logger().Debugf("rendering resource: %s, data passed: %s for cluster: %s", item.ResourceName, data, clusterName)
+	w, err := c.RenderYamlTemplate(item.ResourceName, item.Template, data)
+	if err != nil {
+		return nil, err
+	}
+	if c.ValidateTemplates {
+		if err := validateRendered(item.ResourceName, w.Bytes()); err != nil {
+			c.logger().Debugf("rendered template %s:\n%s", item.ResourceName, w.String())
+			return nil, err
 		}
-		mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient))
-		mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
-		if err != nil {
-			return err
+	}
+	c.logger().Debug("Retreiving GVK....")
+	// decode YAML into unstructured.Unstructured
+	dec := yaml.NewDecodingSerializer(unstructured.UnstructuredJSONScheme)
+	_, gvk, err := dec.Decode(w.Bytes(), nil, obj)
+	if err != nil {
+		c.logger().Debugf("rendered template %s:\n%s", item.ResourceName, w.String())
+		return nil, fmt.Errorf("couldn't decode rendered template %s: %w", item.ResourceName, err)
+	}
+
+	c.logger().Debugf("Retrieved GVK: %s", gvk)
+
+	if len(c.OwnerReferences) > 0 {
+		obj.SetOwnerReferences(append(obj.GetOwnerReferences(), c.OwnerReferences...))
+	}
+
+	c.logger().Debug("Mapping gvk to gvr with discovery client....")
+
+	// Map GVK to GVR with the cached discovery client
+	mapping, err := c.restMapping(ctx, gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		// the hub may serve the kind in another version only, e.g. once ACM promoted it
+		mapping, err = c.restMapping(ctx, gvk.GroupKind())
+		if err == nil {
+			c.logger().Debugf("%s isn't served, using version %s", gvk, mapping.GroupVersionKind.Version)
+			obj.SetAPIVersion(mapping.GroupVersionKind.GroupVersion().String())
+			gvk = &mapping.GroupVersionKind
 		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c.logger().Debug("Mapping has been successfully done")
+	// Build resource
+	resource := schema.GroupVersionResource{
+		Group:    gvk.Group,
+		Version:  gvk.Version,
+		Resource: mapping.Resource.Resource,
+	}
+	if c.DryRun {
+		c.logger().WithFields(log.Fields{"LaunchKubernetesObjects": "DryRun"}).Infof("Dry run, not creating the resource: [%s] (%s) for spoke: [%s]:\n%s", item.ResourceName, resource, clusterName, w.String())
+		launchedResources.WithLabelValues(clusterName, operationDryRun, obj.GetKind()).Inc()
+		return nil, nil
+	}
+	c.logger().WithFields(log.Fields{"LaunchKubernetesObjects": "Creating Resource"}).Debugf("CREATING the resource: [%s] at namespace: [%s] of spoke: [%s] ....", item.ResourceName, data.Namespace, clusterName)
+	var created *unstructured.Unstructured
+	if c.Apply {
+		created, err = c.ApplyKubernetesObjects(ctx, clusterName, obj, resource)
+	} else {
+		created, err = c.CreateKubernetesObjects(ctx, clusterName, obj, resource)
+	}
+	if errors.IsAlreadyExists(err) {
+		// a previous, interrupted run already created it
+		c.logger().WithFields(log.Fields{"LaunchKubernetesObjects": "Exists"}).Infof("The resource: [%s] already exists for spoke: [%s], skipping", item.ResourceName, clusterName)
+		return nil, nil
+	}
+	if err != nil {
+		c.logger().Error(err)
+		return nil, err
+	}
+	if c.ServerDryRun {
+		c.logger().WithFields(log.Fields{"LaunchKubernetesObjects": "ServerDryRun"}).Infof("The resource: [%s] was validated by the hub for spoke: [%s]", item.ResourceName, clusterName)
+		launchedResources.WithLabelValues(clusterName, operationDryRun, obj.GetKind()).Inc()
+		return created, nil
+	}
+	operation := operationCreate
+	if c.Apply {
+		operation = operationApply
+	}
+	launchedResources.WithLabelValues(clusterName, operation, obj.GetKind()).Inc()
 
-		log.Debug("Mapping has been successfully done")
-		// Build resource
-		resource := schema.GroupVersionResource{
-			Group:    gvk.Group,
-			Version:  gvk.Version,
-			Resource: mapping.Resource.Resource,
+	c.logger().Debug(strings.Repeat("-", 60))
+	c.logger().WithFields(log.Fields{"LaunchKubernetesObjects": "Created"}).Debugf("####### Successfully created the resource: [%s] at namespace: %s of spoke: [%s] ... #######", item.ResourceName, data.Namespace, clusterName)
+	c.logger().Debug(strings.Repeat("-", 60))
+	return created, nil
+}
+
+// restMapping maps gk to its resource with the RESTMapper. When the discovery of the hub partially fails,
+// e.g. because an aggregated API server is flaky, the mapper cache is reset and the mapping retried following
+// the retry policy of the client
+// returns:			*meta.RESTMapping, error
+func (c Client) restMapping(ctx context.Context, gk schema.GroupKind, versions ...string) (*meta.RESTMapping, error) {
+	policy := c.retryPolicy()
+
+	for attempt := 1; ; attempt++ {
+		mapping, err := c.RESTMapper.RESTMapping(gk, versions...)
+		if err == nil || !(discovery.IsGroupDiscoveryFailedError(err) || isRetryable(err) || errors.IsServiceUnavailable(err)) {
+			return mapping, err
 		}
-		log.WithFields(log.Fields{"LaunchKubernetesObjects": "Creating Resource"}).Debugf("CREATING the resource: [%s] at namespace: [backupresource] of spoke: [%s] ....", item.ResourceName, clusterName)
-		//	log.Debugf("CREATING the resource: [%s] at namespace: [backupresource] of spoke: [%s] ....", item.ResourceName, clusterName)
-		err = c.CreateKubernetesObjects(clusterName, obj, resource)
-		if err != nil {
-			log.Error(err)
-			return err
+		wait, ok := policy.NextDelay(attempt)
+		if !ok {
+			return nil, err
 		}
 
-		log.Debug(strings.Repeat("-", 60))
-		log.WithFields(log.Fields{"LaunchKubernetesObjects": "Created"}).Debugf("####### Successfully created the resource: [%s] at namespace: backupresource of spoke: [%s] ... #######", item.ResourceName, clusterName)
-		log.Debug(strings.Repeat("-", 60))
+		c.logger().Debugf("discovery failed mapping %s on attempt %d, resetting the cache and retrying in %s: %s", gk, attempt, wait, err)
+		c.RESTMapper.Reset()
 
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
 	}
-	return nil
+}
+
+// targetNamespace returns the namespace targeted on the spoke
+// returns:			string
+func (c Client) targetNamespace() string {
+	if c.Namespace == "" {
+		return DefaultNamespace
+	}
+	return c.Namespace
 }
 
 // RenderYamlTemplate renders a single yaml template
-//            resourceName - resource name
-//            templateBody - template body
+//
+//	resourceName - resource name
+//	templateBody - template body
+//
 // returns:   bytes.Buffer rendered template
-//            error
+//
+//	error
 func (c Client) RenderYamlTemplate(resourceName string, templatebody string, data TemplateData) (*bytes.Buffer, error) {
 
 	w := new(bytes.Buffer)
 
-	//log.Debugf("Parsing template: %s", resourceName)
-	log.WithFields(log.Fields{"Rendertemplate": "Starting"}).Debugf("Parsing template: %s", resourceName)
+	//c.logger().Debugf("Parsing template: %s", resourceName)
+	c.logger().WithFields(log.Fields{"Rendertemplate": "Starting"}).Debugf("Parsing template: %s", resourceName)
 
-	tmpl, err := template.New(resourceName).Parse(commonTemplates + templatebody)
+	// the body is parsed on its own, associated to the common templates, so parse errors point at its lines
+	tmpl, err := commonTemplate.Clone()
 	if err != nil {
+		return w, err
+	}
+	if _, err = tmpl.New(resourceName).Parse(templatebody); err != nil {
 		return w, fmt.Errorf("failed to parse template %s: %v", resourceName, err)
 	}
 	data.ResourceName = resourceName
-	err = tmpl.Execute(w, data)
+	err = tmpl.ExecuteTemplate(w, resourceName, data)
 	if err != nil {
-		return w, fmt.Errorf("failed to render template %s: %v", resourceName, err)
+		return w, fmt.Errorf("failed to render template %s (ResourceName: %q, ClusterName: %q, RecoveryPath: %q, Namespace: %q): %v",
+			resourceName, data.ResourceName, data.ClusterName, data.RecoveryPath, data.Namespace, err)
 	}
-	//	log.Debugf("Successfully parsed template: %s", resourceName)
-	log.WithFields(log.Fields{"Rendertemplate": "Done"}).Debugf("Successfully parsed template: %s", resourceName)
+	//	c.logger().Debugf("Successfully parsed template: %s", resourceName)
+	c.logger().WithFields(log.Fields{"Rendertemplate": "Done"}).Debugf("Successfully parsed template: %s", resourceName)
 	return w, nil
 }
 
 // CreateKubernetesObjects creates specific mca and mcv object targeted to spoke cluster based on
-// unstructured object and gvr
-// returns:			error
-func (c Client) CreateKubernetesObjects(clusterName string, obj *unstructured.Unstructured, resource schema.GroupVersionResource) error {
+// unstructured object and gvr. The object is created in its own metadata.namespace on the hub, or in
+// the cluster namespace when it has none; the spoke namespace it acts upon is set in the rendered template
+// returns:			*unstructured.Unstructured (created resource), error
+func (c Client) CreateKubernetesObjects(ctx context.Context, clusterName string, obj *unstructured.Unstructured, resource schema.GroupVersionResource) (*unstructured.Unstructured, error) {
+
+	namespace := obj.GetNamespace()
+	if namespace == "" {
+		namespace = clusterName
+	}
+
+	var created *unstructured.Unstructured
+	err := c.withRetry(ctx, func() error {
+		opCtx, cancel := c.operationContext(ctx)
+		defer cancel()
+		var err error
+		created, err = c.KubernetesClient.Resource(resource).Namespace(namespace).Create(opCtx, obj, v1.CreateOptions{DryRun: c.dryRunOption()})
+		return err
+	})
+	if errors.IsNotFound(err) && c.namespaceMissing(ctx, namespace) {
+		return nil, fmt.Errorf("%w: cluster namespace %s not found on hub; is the spoke imported?", ErrClusterNamespaceNotFound, namespace)
+	}
+	if err != nil {
+		c.logger().Debugf("err is : %s", err)
+		return nil, err
+	}
+	return created, nil
+}
+
+// contentHash returns the hash of the rendered resource without the metadata of the run, i.e. its RunIDLabel,
+// its owner references and its ContentHashAnnotation, so rendering the same template in another run gives the same hash
+// returns:			string, error
+func contentHash(obj *unstructured.Unstructured) (string, error) {
+	content := obj.DeepCopy()
+	unstructured.RemoveNestedField(content.Object, "metadata", "labels", RunIDLabel)
+	unstructured.RemoveNestedField(content.Object, "metadata", "annotations", ContentHashAnnotation)
+	unstructured.RemoveNestedField(content.Object, "metadata", "ownerReferences")
 
-	_, err := c.KubernetesClient.Resource(resource).Namespace(clusterName).Create(context.Background(), obj, v1.CreateOptions{})
+	data, err := content.MarshalJSON()
 	if err != nil {
-		log.Debugf("err is : %s", err)
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// ApplyKubernetesObjects creates or updates the resource with a server-side apply owned by c.FieldManager,
+// so re-running with an updated template reconciles the resource instead of failing. Fields owned by another
+// manager make the apply fail with a conflict unless c.ForceApply is set, which takes their ownership. The hash of the rendered
+// resource, without the metadata of the run, is stored in its ContentHashAnnotation and the apply is skipped when the
+// live resource has the same one
+// returns:			*unstructured.Unstructured (applied or unchanged live resource), error
+func (c Client) ApplyKubernetesObjects(ctx context.Context, clusterName string, obj *unstructured.Unstructured, resource schema.GroupVersionResource) (*unstructured.Unstructured, error) {
+
+	namespace := obj.GetNamespace()
+	if namespace == "" {
+		namespace = clusterName
+	}
+
+	hash, err := contentHash(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	opCtx, cancel := c.operationContext(ctx)
+	live, err := c.KubernetesClient.Resource(resource).Namespace(namespace).Get(opCtx, obj.GetName(), v1.GetOptions{})
+	cancel()
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	if err == nil && live.GetAnnotations()[ContentHashAnnotation] == hash {
+		c.logger().Debugf("the resource %s of cluster %s is unchanged, skipping the apply", obj.GetName(), clusterName)
+		return live, nil
+	}
+
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[ContentHashAnnotation] = hash
+	obj.SetAnnotations(annotations)
+	data, err := obj.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	var applied *unstructured.Unstructured
+	err = c.withRetry(ctx, func() error {
+		opCtx, cancel := c.operationContext(ctx)
+		defer cancel()
+		var err error
+		applied, err = c.KubernetesClient.Resource(resource).Namespace(namespace).Patch(opCtx, obj.GetName(), types.ApplyPatchType, data, c.patchOptions())
 		return err
+	})
+	if errors.IsNotFound(err) && c.namespaceMissing(ctx, namespace) {
+		return nil, fmt.Errorf("%w: cluster namespace %s not found on hub; is the spoke imported?", ErrClusterNamespaceNotFound, namespace)
+	}
+	if errors.IsConflict(err) && !c.ForceApply {
+		return nil, fmt.Errorf("the apply of %s conflicts with fields owned by another manager, set ForceApply to take their ownership: %w", obj.GetName(), err)
+	}
+	if err != nil {
+		c.logger().Debugf("err is : %s", err)
+		return nil, err
+	}
+	return applied, nil
+}
+
+// patchOptions returns the options of the server-side applies, owned by c.FieldManager or DefaultFieldManager
+// returns:			v1.PatchOptions
+func (c Client) patchOptions() v1.PatchOptions {
+	opts := v1.PatchOptions{FieldManager: c.FieldManager, DryRun: c.dryRunOption()}
+	if opts.FieldManager == "" {
+		opts.FieldManager = DefaultFieldManager
+	}
+	if c.ForceApply {
+		force := true
+		opts.Force = &force
+	}
+	return opts
+}
+
+// dryRun tells whether nothing is persisted on the hub, because c.DryRun or c.ServerDryRun is set
+// returns:			bool
+func (c Client) dryRun() bool {
+	return c.DryRun || c.ServerDryRun
+}
+
+// dryRunOption returns the DryRun option of the create and apply calls, validating the resources on the hub
+// without persisting them when c.ServerDryRun is set
+// returns:			[]string
+func (c Client) dryRunOption() []string {
+	if c.ServerDryRun {
+		return []string{v1.DryRunAll}
 	}
 	return nil
 }
 
-// ManageObjects can query and delete k8s resource
-// returns:			*unstructured.Unstructured (view data)
-//                   error
-func (c Client) ManageObjects(clusterName string, template []ResourceTemplate, resourceType string, action string) (*unstructured.Unstructured, error) {
+// namespaceMissing checks whether namespace doesn't exist on the hub, lookup errors are
+// reported as an existing namespace so the original error is returned to the caller
+// returns:			bool
+func (c Client) namespaceMissing(ctx context.Context, namespace string) bool {
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+
+	opCtx, cancel := c.operationContext(ctx)
+	defer cancel()
+	_, err := c.KubernetesClient.Resource(gvr).Get(opCtx, namespace, v1.GetOptions{})
+	return errors.IsNotFound(err)
+}
+
+// resourceGVR returns the GroupVersionResource of a managedclusteraction (MCA) or managedclusterview (MCV),
+// in the version served by the hub
+// returns:			schema.GroupVersionResource, error
+func (c Client) resourceGVR(resourceType string) (schema.GroupVersionResource, error) {
+	switch resourceType {
+	case MCA:
+		return c.servedGVR(ManagedClusterActionGVR), nil
+	case MCV:
+		return c.servedGVR(ManagedClusterViewGVR), nil
+	default:
+		return schema.GroupVersionResource{}, fmt.Errorf("unsupported resource type: %s", resourceType)
+	}
+}
 
-	gvr := schema.GroupVersionResource{
-		Group:    "view.open-cluster-management.io",
-		Version:  "v1beta1",
-		Resource: resourceType,
+// servedGVR returns gvr in the version preferred by the hub, so the client keeps working when ACM promotes
+// its resources to a new version. gvr is returned as is when the hub doesn't serve its resource or can't be asked
+// returns:			schema.GroupVersionResource
+func (c Client) servedGVR(gvr schema.GroupVersionResource) schema.GroupVersionResource {
+	if c.RESTMapper == nil {
+		return gvr
+	}
+	served, err := c.RESTMapper.ResourceFor(schema.GroupVersionResource{Group: gvr.Group, Resource: gvr.Resource})
+	if err != nil {
+		c.logger().Debugf("couldn't discover the served version of %s, using %s: %s", gvr.GroupResource(), gvr.Version, err)
+		return gvr
+	}
+	if served.Version != gvr.Version {
+		c.logger().Debugf("the hub serves %s in version %s", gvr.GroupResource(), served.Version)
 	}
+	return served
+}
 
-	var view *unstructured.Unstructured
+// ManageObjects can query and delete managedclusteractions (MCA) and managedclusterviews (MCV).
+// With the "get" action every resource of template is fetched and returned in the template order,
+// "getIfExists" does the same but returns a nil object instead of failing for the resources not found,
+// so callers polling for a resource not created yet can keep waiting. With the "delete" action every resource of template is deleted and no object is returned
+// returns:			[]*unstructured.Unstructured (view data), error
+func (c Client) ManageObjects(ctx context.Context, clusterName string, template []ResourceTemplate, resourceType string, action string) ([]*unstructured.Unstructured, error) {
+	if err := ValidateClusterName(clusterName); err != nil {
+		return nil, err
+	}
+
+	gvr, err := c.resourceGVR(resourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	var views []*unstructured.Unstructured
 
 	for _, items := range template {
 		switch action {
-		case "get":
-			view, err := c.KubernetesClient.Resource(gvr).Namespace(clusterName).Get(context.Background(), items.ResourceName, v1.GetOptions{})
+		case "get", "getIfExists":
+			var view *unstructured.Unstructured
+			err := c.withRetry(ctx, func() error {
+				opCtx, cancel := c.operationContext(ctx)
+				defer cancel()
+				var err error
+				view, err = c.KubernetesClient.Resource(gvr).Namespace(clusterName).Get(opCtx, items.ResourceName, v1.GetOptions{})
+				return err
+			})
+			if errors.IsNotFound(err) && action == "getIfExists" {
+				c.logger().Debugf("%s %s of cluster %s doesn't exist yet", resourceType, items.ResourceName, clusterName)
+				views = append(views, nil)
+				continue
+			}
 			if err != nil {
-				return view, err
+				return views, err
 			}
-			return view, nil
+			views = append(views, view)
 
 		case "delete":
-			err := c.KubernetesClient.Resource(gvr).Namespace(clusterName).Delete(context.Background(), items.ResourceName, v1.DeleteOptions{})
+			opCtx, cancel := c.operationContext(ctx)
+			err := c.KubernetesClient.Resource(gvr).Namespace(clusterName).Delete(opCtx, items.ResourceName, v1.DeleteOptions{})
+			cancel()
 			if err != nil {
 				return nil, err
 			}
-			log.WithFields(log.Fields{"DeleteObject": "Done"}).Debugf("####### Successfully deleted the %s resource named: [%s] for cluster: %s #######", resourceType, items.ResourceName, clusterName)
-		
-      default:
+			deletedResources.WithLabelValues(clusterName, resourceKind(resourceType)).Inc()
+			c.logger().WithFields(log.Fields{"DeleteObject": "Done"}).Debugf("####### Successfully deleted the %s resource named: [%s] for cluster: %s #######", resourceType, items.ResourceName, clusterName)
+
+		default:
 			return nil, fmt.Errorf("no condition matched")
 		}
 	}
-	return view, nil
+	return views, nil
 }
 
-// ViewProcessing checks whether managedclusterview is processing or complete
-// returns: 	processing bool
+// ViewProcessing checks whether managedclusterview is processing or complete,
+// malformed or partially populated conditions are skipped
+// returns: 	status, type of the last usable condition, both empty when none is found
 func (c Client) ViewProcessing(viewConditions []interface{}) (string, string) {
 
 	var status, t string
 	for _, condition := range viewConditions {
-		status = condition.(map[string]interface{})["status"].(string)
-		t = condition.(map[string]interface{})["type"].(string)
-		log.Debugf("job status from mcv status: [%s], type: [%s]", status, t)
+		fields, ok := condition.(map[string]interface{})
+		if !ok {
+			c.logger().Debugf("skipping malformed mcv condition: %v", condition)
+			continue
+		}
+		s, ok := fields["status"].(string)
+		if !ok {
+			c.logger().Debugf("skipping mcv condition without status: %v", fields)
+			continue
+		}
+		ty, ok := fields["type"].(string)
+		if !ok {
+			c.logger().Debugf("skipping mcv condition without type: %v", fields)
+			continue
+		}
+		status, t = s, ty
+		c.logger().Debugf("job status from mcv status: [%s], type: [%s]", status, t)
 	}
 	return status, t
 }
 
-// JobStatus uses timeout to verify the state of the job in a predefined window
+// JobStatus uses timeout to verify the state of the job in a predefined window,
+// the window and polling cadence are taken from c.StatusOptions
 // returns: 	error
-func (c Client) JobStatus(clusterName string, action string) error {
+func (c Client) JobStatus(ctx context.Context, clusterName string, action string) error {
+	return c.waitForViewStatus(ctx, clusterName, c.templates().ViewCreate, action)
+}
 
-	ticker := time.NewTicker(time.Second * time.Duration(TimeInterval)).C
-	timeout := time.After(time.Second * time.Duration(TimeOut))
+// waitForViewStatus polls the managedclusterview rendered from views until it reports action or times out
+// returns: 	error
+func (c Client) waitForViewStatus(ctx context.Context, clusterName string, views []ResourceTemplate, action string) error {
+	start := time.Now()
+	defer func() {
+		statusWaitSeconds.WithLabelValues(clusterName, action).Observe(time.Since(start).Seconds())
+	}()
 
-OuterLoop:
-	for {
-		select {
-		case <-timeout:
-			log.WithFields(log.Fields{"timeout": "Checking"}).Debug("function timedout")
-			return fmt.Errorf("couldn't verify the backup job completion before a predefined time window")
-
-		case <-ticker:
-			if err := c.CheckStatus(MCV, clusterName, action); err != nil {
-				fmt.Printf("err: %v", err)
-			} else {
-				break OuterLoop
-			}
+	var lastErr error
+	err := c.pollUntil(ctx, fmt.Errorf("%w: couldn't verify the job was %s on cluster %s", ErrViewTimeout, action, clusterName), func(ctx context.Context) (bool, error) {
+		err := c.checkViewStatus(ctx, MCV, clusterName, views, action)
+		if err == nil || goerrors.Is(err, ErrJobFailed) || isRetryable(err) {
+			return err == nil, err
+		}
+		lastErr = err
+		if !goerrors.Is(err, ErrConditionsPending) && !goerrors.Is(err, ErrViewNotCreated) {
+			c.logger().Debugf("couldn't check the view status of cluster %s: %s", clusterName, err)
+		}
+		return false, nil
+	})
+	if goerrors.Is(err, ErrViewTimeout) {
+		c.logger().WithFields(log.Fields{"timeout": "Checking"}).Debug("function timedout")
+		statusTimeouts.WithLabelValues(clusterName, action).Inc()
+		if lastErr != nil {
+			return fmt.Errorf("%w, last status: %s", err, lastErr)
 		}
 	}
-	return nil
+	return err
 }
 
 // CheckStatus checks whether the job launched on the spoke was successfully launched and finished
 // returns: 	error
-func (c Client) CheckStatus(resourceType string, clusterName string, action string) error {
+func (c Client) CheckStatus(ctx context.Context, resourceType string, clusterName string, action string) error {
+	_, err := c.CheckStatusResult(ctx, resourceType, clusterName, action)
+	return err
+}
 
-	log.Debug("####### Checking status of kubernetes job #######")
+// CheckStatusResult checks the job status like CheckStatus and returns the status.result payload
+// of the managedclusterview, so the caller can record what the job actually did on the spoke
+// returns: 	map[string]interface{} (view result), error
+func (c Client) CheckStatusResult(ctx context.Context, resourceType string, clusterName string, action string) (map[string]interface{}, error) {
+	clusterView, err := c.getStatusView(ctx, resourceType, clusterName, c.templates().ViewCreate)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.viewStatus(clusterView, clusterName, action); err != nil {
+		return nil, err
+	}
+	result, _, err := unstructured.NestedMap(clusterView.Object, "status", "result")
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
 
+// CheckViewStatus checks the job status like CheckStatus, reading the managedclusterview named viewName
+// instead of the one of the view templates, e.g. a view created with CreateView
+// returns: 	error
+func (c Client) CheckViewStatus(ctx context.Context, clusterName string, viewName string, action string) error {
+	if viewName == "" {
+		return c.CheckStatus(ctx, MCV, clusterName, action)
+	}
+	return c.checkViewStatus(ctx, MCV, clusterName, []ResourceTemplate{{ResourceName: viewName}}, action)
+}
 
-	clusterView, err := c.ManageObjects(clusterName, ViewCreateTemplates, resourceType, "get")
+// checkViewStatus checks the job status reported by the managedclusterview rendered from views
+// returns: 	error
+func (c Client) checkViewStatus(ctx context.Context, resourceType string, clusterName string, views []ResourceTemplate, action string) error {
+	clusterView, err := c.getStatusView(ctx, resourceType, clusterName, views)
 	if err != nil {
-		log.Errorf("Couldn't find managedclusterview from %s cluster; err: %s", c.Spoke, err)
 		return err
 	}
-	log.Debug("Found managedclusterview object")
+	return c.viewStatus(clusterView, clusterName, action)
+}
+
+// getStatusView gets the first managedclusterview rendered from views
+// returns: 	*unstructured.Unstructured, error
+func (c Client) getStatusView(ctx context.Context, resourceType string, clusterName string, views []ResourceTemplate) (*unstructured.Unstructured, error) {
+
+	c.logger().Debug("####### Checking status of kubernetes job #######")
+
+	clusterViews, err := c.ManageObjects(ctx, clusterName, views[:1], resourceType, "getIfExists")
+	if err != nil {
+		c.logger().Errorf("Couldn't find managedclusterview from %s cluster; err: %s", clusterName, err)
+		return nil, err
+	}
+	if clusterViews[0] == nil {
+		// the view may not be created yet, which isn't a failure while polling
+		return nil, fmt.Errorf("%w: %s %s for cluster: %s", ErrViewNotCreated, resourceType, views[0].ResourceName, clusterName)
+	}
+	c.logger().Debug("Found managedclusterview object")
+
+	return clusterViews[0], nil
+}
+
+// conditionMessage returns the reason and message of a condition, for error messages
+// returns:			string
+func conditionMessage(cond map[string]interface{}) string {
+	reason, _ := cond["reason"].(string)
+	message, _ := cond["message"].(string)
+	switch {
+	case reason == "":
+		return message
+	case message == "":
+		return reason
+	default:
+		return reason + ": " + message
+	}
+}
+
+// jobFailure checks whether the job watched by the managedclusterview reports the Failed condition
+// returns: 	error wrapping ErrJobFailed with the job message, nil otherwise
+func jobFailure(clusterView *unstructured.Unstructured, clusterName string) error {
+	conditions, found, err := unstructured.NestedSlice(clusterView.Object, "status", "result", "status", "conditions")
+	if err != nil || !found {
+		// a missing or malformed result is reported by viewStatus
+		return nil
+	}
+	for _, condition := range conditions {
+		cond, ok := condition.(map[string]interface{})
+		if !ok || cond["type"] != "Failed" || cond["status"] != "True" {
+			continue
+		}
+		job, _, _ := unstructured.NestedString(clusterView.Object, "status", "result", "metadata", "name")
+		return fmt.Errorf("%w on spoke %s (job %s): %s", ErrJobFailed, clusterName, job, conditionMessage(cond))
+	}
+	return nil
+}
+
+// viewStatus checks the job status reported by an already fetched managedclusterview
+// returns: 	error, nil once the view reports the job as launched or complete
+func (c Client) viewStatus(clusterView *unstructured.Unstructured, clusterName string, action string) error {
+
+	if err := jobFailure(clusterView, clusterName); err != nil {
+		return err
+	}
 
 	// since we are using same function for verifying if the job launched or finished, the conditions will vary
 	var matchingCondition []string
@@ -389,25 +1393,37 @@ func (c Client) CheckStatus(resourceType string, clusterName string, action stri
 	conditions, exists, err := unstructured.NestedSlice(clusterView.Object, matchingCondition...)
 
 	if err != nil {
-		log.Error(err)
+		c.logger().Error(err)
 		return err
 	}
-	log.Debugf("conditions: %s", conditions)
+	c.logger().Debugf("conditions: %s", conditions)
 	if !exists {
 		return fmt.Errorf("unable to traverse object, maybe result field is yet not available")
 	}
+	if len(conditions) == 0 {
+		// the view is accepted but isn't reporting yet, unlike a missing field this is expected right after creation
+		c.logger().Debugf("managedclusterview for cluster %s has no condition yet, waiting", clusterName)
+		return fmt.Errorf("%w for cluster: %s", ErrConditionsPending, clusterName)
+	}
 	value, t := c.ViewProcessing(conditions)
+	if t == "" {
+		return fmt.Errorf("no usable condition found in managedclusterview for cluster: %s", clusterName)
+	}
 	if value == "True" {
 		switch t {
 		case "Processing":
-			log.Debug("The job has successfully launched")
+			c.logger().Debug("The job has successfully launched")
 			return nil
 		case "Complete":
-			log.Debug("The job has successfully finished")
+			c.logger().Debug("The job has successfully finished")
 			return nil
 		}
 	}
 
-	return fmt.Errorf("expecting the status to be either Processing or Complete but found: %s for cluster: %s", t, clusterName)
+	message := ""
+	if last, ok := conditions[len(conditions)-1].(map[string]interface{}); ok {
+		message = conditionMessage(last)
+	}
+	return fmt.Errorf("expecting the status to be either Processing or Complete but found: %s %s for cluster: %s: %s", t, value, clusterName, message)
 
 }
@@ -0,0 +1,261 @@
+package client
+
+import (
+	"context"
+	goerrors "errors"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer/yaml"
+	"k8s.io/client-go/dynamic"
+)
+
+// renderTemplate renders the template named name of the ActionCreate templates of c for spoke
+func renderTemplate(t *testing.T, c Client, name string) *unstructured.Unstructured {
+	for _, item := range c.templates().ActionCreate {
+		if item.ResourceName != name {
+			continue
+		}
+		w, err := c.RenderYamlTemplate(item.ResourceName, item.Template, c.templateData("spoke"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		obj := &unstructured.Unstructured{}
+		if _, _, err := yaml.NewDecodingSerializer(unstructured.UnstructuredJSONScheme).Decode(w.Bytes(), nil, obj); err != nil {
+			t.Fatal(err)
+		}
+		return obj
+	}
+	t.Fatalf("no template %s", name)
+	return nil
+}
+
+func TestContentHashIgnoresRunMetadata(t *testing.T) {
+	c := newClient([]string{"spoke"}, DefaultBackupPath, "")
+	c.RunID = "0a1b2c3d"
+	first := renderTemplate(t, c, "backup-create-job")
+
+	c.RunID = "4e5f6a7b"
+	c.OwnerReferences = []metav1.OwnerReference{{APIVersion: "v1", Kind: "ConfigMap", Name: "owner", UID: "1234"}}
+	second := renderTemplate(t, c, "backup-create-job")
+
+	c.Image = "quay.io/example/backup:other"
+	changed := renderTemplate(t, c, "backup-create-job")
+
+	firstHash, err := contentHash(first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondHash, err := contentHash(second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changedHash, err := contentHash(changed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if firstHash != secondHash {
+		t.Errorf("the hash changed with the run id and the owner references")
+	}
+	if secondHash == changedHash {
+		t.Errorf("the hash didn't change with the image")
+	}
+}
+
+// failingHub is a dynamic client whose reads fail with err
+type failingHub struct {
+	err error
+}
+
+func (h failingHub) Resource(gvr schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	return failingResource{err: h.err}
+}
+
+type failingResource struct {
+	dynamic.NamespaceableResourceInterface
+	err error
+}
+
+func (r failingResource) Namespace(string) dynamic.ResourceInterface {
+	return r
+}
+
+func (r failingResource) Get(context.Context, string, metav1.GetOptions, ...string) (*unstructured.Unstructured, error) {
+	return nil, r.err
+}
+
+func TestApplyReturnsGetErrors(t *testing.T) {
+	c := newClient([]string{"spoke"}, DefaultBackupPath, "")
+	c.KubernetesClient = failingHub{err: errors.NewForbidden(ManagedClusterActionGVR.GroupResource(), "backup-create-job", nil)}
+	obj := renderTemplate(t, c, "backup-create-job")
+
+	if _, err := c.ApplyKubernetesObjects(context.Background(), "spoke", obj, ManagedClusterActionGVR); !errors.IsForbidden(err) {
+		t.Errorf("got %v, want the forbidden error of the get", err)
+	}
+}
+
+func TestLaunchSkipsExistingResources(t *testing.T) {
+	hub := newSpokeHub(t)
+	first := newBackupClient(t, hub)
+	first.RunID = "interrupted-run"
+	templates := first.templates().ActionCreate
+	// the run was interrupted after creating the namespace action
+	if err := first.LaunchKubernetesObjects(context.Background(), "spoke", templates[:1]); err != nil {
+		t.Fatal(err)
+	}
+
+	c := newBackupClient(t, hub)
+	c.RunID = "rerun"
+	if err := c.LaunchKubernetesObjects(context.Background(), "spoke", templates); err != nil {
+		t.Fatalf("got %s, want the existing resource to be skipped", err)
+	}
+	actions, err := c.ManageObjects(context.Background(), "spoke", templates, MCA, "get")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, action := range actions {
+		want := "rerun"
+		if i == 0 {
+			want = "interrupted-run"
+		}
+		if got := action.GetLabels()[RunIDLabel]; got != want {
+			t.Errorf("action %s belongs to run %q, want %q", action.GetName(), got, want)
+		}
+	}
+}
+
+func TestViewProcessing(t *testing.T) {
+	c := newClient([]string{"spoke"}, DefaultBackupPath, "")
+
+	for _, tc := range []struct {
+		name       string
+		conditions []interface{}
+		wantStatus string
+		wantType   string
+	}{
+		{name: "no condition"},
+		{
+			name:       "processing",
+			conditions: []interface{}{condition("Processing", "True", "", "")},
+			wantStatus: "True",
+			wantType:   "Processing",
+		},
+		{
+			name:       "last usable condition wins",
+			conditions: []interface{}{condition("Processing", "True", "", ""), condition("Complete", "False", "", "")},
+			wantStatus: "False",
+			wantType:   "Complete",
+		},
+		{
+			name: "malformed conditions are skipped",
+			conditions: []interface{}{
+				condition("Processing", "True", "", ""),
+				"malformed",
+				map[string]interface{}{"type": "Complete"},
+				map[string]interface{}{"status": "True"},
+			},
+			wantStatus: "True",
+			wantType:   "Processing",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			status, conditionType := c.ViewProcessing(tc.conditions)
+			if status != tc.wantStatus || conditionType != tc.wantType {
+				t.Errorf("got %q, %q, want %q, %q", status, conditionType, tc.wantStatus, tc.wantType)
+			}
+		})
+	}
+}
+
+// condition returns a kubernetes condition as decoded in an unstructured object, empty fields are left out
+func condition(conditionType string, status string, reason string, message string) interface{} {
+	cond := map[string]interface{}{"type": conditionType, "status": status}
+	if reason != "" {
+		cond["reason"] = reason
+	}
+	if message != "" {
+		cond["message"] = message
+	}
+	return cond
+}
+
+func TestViewStatusFailsFast(t *testing.T) {
+	c := newClient([]string{"spoke"}, DefaultBackupPath, "")
+
+	for _, tc := range []struct {
+		name    string
+		result  map[string]interface{}
+		wantErr bool
+	}{
+		{name: "job running", result: jobResult(nil)},
+		{name: "job failed", result: jobResult(nil, condition("Failed", "True", "BackoffLimitExceeded", "")), wantErr: true},
+		{name: "failed condition not true", result: jobResult(nil, condition("Failed", "False", "", ""))},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			view := &unstructured.Unstructured{Object: map[string]interface{}{"status": map[string]interface{}{
+				"conditions": []interface{}{condition("Processing", "True", "", "")},
+				"result":     tc.result,
+			}}}
+			// the failure is reported while waiting for the launch already
+			err := c.viewStatus(view, "spoke", Launch)
+			if goerrors.Is(err, ErrJobFailed) != tc.wantErr {
+				t.Errorf("got %v, want an ErrJobFailed error %t", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+// jobResult returns a job as returned in a view result, with annotations and status conditions
+func jobResult(annotations map[string]string, conditions ...interface{}) map[string]interface{} {
+	metadata := map[string]interface{}{"name": "backupresource"}
+	if len(annotations) > 0 {
+		values := map[string]interface{}{}
+		for k, v := range annotations {
+			values[k] = v
+		}
+		metadata["annotations"] = values
+	}
+	if conditions == nil {
+		conditions = []interface{}{}
+	}
+	return map[string]interface{}{
+		"metadata": metadata,
+		"status":   map[string]interface{}{"conditions": conditions},
+	}
+}
+
+func TestJobFailure(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		result  map[string]interface{}
+		wantErr string
+	}{
+		{name: "no result yet"},
+		{name: "job complete", result: jobResult(nil, condition("Complete", "True", "", ""))},
+		{
+			name:    "reason and message",
+			result:  jobResult(nil, condition("Failed", "True", "BackoffLimitExceeded", "Job has reached the specified backoff limit")),
+			wantErr: "job failed on spoke spoke (job backupresource): BackoffLimitExceeded: Job has reached the specified backoff limit",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			status := map[string]interface{}{}
+			if tc.result != nil {
+				status["result"] = tc.result
+			}
+			err := jobFailure(&unstructured.Unstructured{Object: map[string]interface{}{"status": status}}, "spoke")
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("got %s, want no error", err)
+				}
+				return
+			}
+			if !goerrors.Is(err, ErrJobFailed) || err.Error() != tc.wantErr {
+				t.Errorf("got %v, want %q wrapping ErrJobFailed", err, tc.wantErr)
+			}
+		})
+	}
+}
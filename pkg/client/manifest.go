@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ManifestAnnotation is the annotation the backup job of backup-image sets on itself to record what it backed up,
+// as a JSON list of BackupItem
+const ManifestAnnotation = "sno-upgrade-recovery/manifest"
+
+// BackupItem is a file or resource saved by the backup job
+type BackupItem struct {
+	// Path is the location of the item in the backup path of the spoke
+	Path string `json:"path"`
+	// Kind tells what was backed up, e.g. a file, a directory or a kubernetes resource
+	Kind string `json:"kind,omitempty"`
+	// Size is the size of the item in bytes, zero when the job doesn't report it
+	Size int64 `json:"size,omitempty"`
+}
+
+// GetBackupManifest reads the managedclusterview of the completed backup job of clusterName and decodes
+// the list of backed up items the job recorded in its ManifestAnnotation
+// returns:			[]BackupItem, error
+func (c Client) GetBackupManifest(ctx context.Context, clusterName string) ([]BackupItem, error) {
+	result, err := c.viewResult(ctx, clusterName, c.templates().ViewCreate)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeBackupManifest(result)
+}
+
+// DecodeBackupManifest decodes the backed up items recorded by the job returned in a view result
+// returns:			[]BackupItem, error
+func DecodeBackupManifest(result map[string]interface{}) ([]BackupItem, error) {
+	job := DecodeJobResult(result)
+	if !job.Succeeded {
+		return nil, fmt.Errorf("the backup job hasn't completed, no manifest is available")
+	}
+
+	manifest, found, err := unstructured.NestedString(result, "metadata", "annotations", ManifestAnnotation)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("the backup job didn't record a manifest in its %s annotation", ManifestAnnotation)
+	}
+
+	var items []BackupItem
+	if err := json.Unmarshal([]byte(manifest), &items); err != nil {
+		return nil, fmt.Errorf("couldn't decode the backup manifest: %w", err)
+	}
+	return items, nil
+}
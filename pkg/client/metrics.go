@@ -0,0 +1,66 @@
+package client
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const metricsNamespace = "sno_upgrade_recovery"
+
+// Values of the operation label of launched_resources_total, telling the created, server side applied and
+// dry run resources apart
+const (
+	operationCreate = "create"
+	operationApply  = "apply"
+	operationDryRun = "dry-run"
+)
+
+var (
+	launchedResources = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "launched_resources_total",
+		Help:      "Number of managedclusteractions and managedclusterviews created, applied or dry run on the hub",
+	}, []string{"spoke", "operation", "kind"})
+
+	deletedResources = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "deleted_resources_total",
+		Help:      "Number of managedclusteractions and managedclusterviews deleted from the hub",
+	}, []string{"spoke", "kind"})
+
+	statusTimeouts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "status_timeouts_total",
+		Help:      "Number of job status checks that timed out",
+	}, []string{"spoke", "status"})
+
+	statusWaitSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "status_wait_seconds",
+		Help:      "Time spent waiting for a managedclusterview to report the job status",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 10),
+	}, []string{"spoke", "status"})
+)
+
+// RegisterMetrics registers the backup and recovery metrics with registry
+// returns:			error
+func RegisterMetrics(registry prometheus.Registerer) error {
+	for _, collector := range []prometheus.Collector{launchedResources, deletedResources, statusTimeouts, statusWaitSeconds} {
+		if err := registry.Register(collector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resourceKind returns the kind of the managedclusteractions (MCA) or managedclusterviews (MCV)
+// returns:			string
+func resourceKind(resourceType string) string {
+	switch resourceType {
+	case MCA:
+		return "ManagedClusterAction"
+	case MCV:
+		return "ManagedClusterView"
+	default:
+		return resourceType
+	}
+}
@@ -0,0 +1,138 @@
+package client
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Option sets a setting of the client built by NewWithOptions, on top of the defaults
+type Option func(*Client)
+
+// WithBackupPath sets the path of the recovery partition where the spokes store their backups
+// returns:			Option
+func WithBackupPath(backupPath string) Option {
+	return func(c *Client) {
+		c.BackupPath = backupPath
+	}
+}
+
+// WithKubeconfigPath sets the kubeconfig of the hub, the in-cluster config is used when it's empty
+// returns:			Option
+func WithKubeconfigPath(kubeconfigPath string) Option {
+	return func(c *Client) {
+		c.KubeconfigPath = kubeconfigPath
+	}
+}
+
+// WithImage sets the image running the jobs on the spokes, and the secret pulling it when pullSecret isn't empty
+// returns:			Option
+func WithImage(image string, pullSecret string) Option {
+	return func(c *Client) {
+		c.Image = image
+		c.ImagePullSecret = pullSecret
+	}
+}
+
+// WithNamespace sets the namespace created on the spokes to run the jobs
+// returns:			Option
+func WithNamespace(namespace string) Option {
+	return func(c *Client) {
+		c.Namespace = namespace
+	}
+}
+
+// WithServiceAccount sets the service account running the jobs and the cluster role bound to it
+// returns:			Option
+func WithServiceAccount(serviceAccountName string, clusterRoleName string) Option {
+	return func(c *Client) {
+		c.ServiceAccountName = serviceAccountName
+		c.ClusterRoleName = clusterRoleName
+	}
+}
+
+// WithResources sets the resource requests and limits of the job containers, empty values are left unset
+// returns:			Option
+func WithResources(cpuRequest string, memRequest string, cpuLimit string, memLimit string) Option {
+	return func(c *Client) {
+		c.CPURequest = cpuRequest
+		c.MemRequest = memRequest
+		c.CPULimit = cpuLimit
+		c.MemLimit = memLimit
+	}
+}
+
+// WithVolume adds a volume to the jobs, mounted in their container by mount
+// returns:			Option
+func WithVolume(volume corev1.Volume, mount corev1.VolumeMount) Option {
+	return func(c *Client) {
+		c.Volumes = append(c.Volumes, volume)
+		c.VolumeMounts = append(c.VolumeMounts, mount)
+	}
+}
+
+// WithScheduling sets the nodeSelector and the tolerations of the jobs
+// returns:			Option
+func WithScheduling(nodeSelector map[string]string, tolerations []corev1.Toleration) Option {
+	return func(c *Client) {
+		c.NodeSelector = nodeSelector
+		c.Tolerations = tolerations
+	}
+}
+
+// WithBackupPaths sets the backup paths of the spokes overriding the one set by WithBackupPath
+// returns:			Option
+func WithBackupPaths(backupPaths map[string]string) Option {
+	return func(c *Client) {
+		c.BackupPaths = backupPaths
+	}
+}
+
+// WithTLS sets the CA bundle verifying the hub instead of the kubeconfig one when caData isn't empty,
+// and skips the verification of the hub certificate when insecure is set
+// returns:			Option
+func WithTLS(caData []byte, insecure bool) Option {
+	return func(c *Client) {
+		c.CAData = caData
+		c.Insecure = insecure
+	}
+}
+
+// WithRateLimits sets the maximum queries per second to the hub and their burst, zero values keep the client-go defaults
+// returns:			Option
+func WithRateLimits(qps float32, burst int) Option {
+	return func(c *Client) {
+		c.QPS = qps
+		c.Burst = burst
+	}
+}
+
+// WithConnectTimeout sets the maximum time to connect to the hub
+// returns:			Option
+func WithConnectTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.ConnectTimeout = timeout
+	}
+}
+
+// WithDiscoveryCache caches the discovery data of the hub in dir for ttl, DefaultDiscoveryCacheDir and
+// DefaultDiscoveryCacheTTL are used when they're empty
+// returns:			Option
+func WithDiscoveryCache(dir string, ttl time.Duration) Option {
+	return func(c *Client) {
+		if dir == "" {
+			dir = DefaultDiscoveryCacheDir()
+		}
+		c.DiscoveryCacheDir = dir
+		c.DiscoveryCacheTTL = ttl
+	}
+}
+
+// WithLogger sets the logger of the client
+// returns:			Option
+func WithLogger(logger log.FieldLogger) Option {
+	return func(c *Client) {
+		c.Logger = logger
+	}
+}
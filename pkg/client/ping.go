@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	memory "k8s.io/client-go/discovery/cached"
+)
+
+// Ping checks with CheckCRDs that the hub serves the managedcluster, managedclusteraction and
+// managedclusterview resources and that it is reachable, so a batch of backups can fail fast before any work begins
+// returns:			error
+func (c Client) Ping(ctx context.Context) error {
+	if c.KubernetesClient == nil || c.DiscoveryClient == nil {
+		return fmt.Errorf("the client has no kubernetes client, it must be created with New")
+	}
+
+	if err := c.CheckCRDs(ctx); err != nil {
+		return err
+	}
+
+	gvr := c.servedGVR(ManagedClusterGVR)
+
+	opCtx, cancel := c.operationContext(ctx)
+	defer cancel()
+	if _, err := c.KubernetesClient.Resource(gvr).List(opCtx, v1.ListOptions{Limit: 1}); err != nil {
+		return fmt.Errorf("couldn't list %s on the hub: %w", gvr.Resource, err)
+	}
+
+	c.logger().Debug("the hub is reachable and serves the expected resources")
+	return nil
+}
+
+// RequiredResources are the hub resources checked by CheckCRDs, installed by ACM
+var RequiredResources = []schema.GroupVersionResource{ManagedClusterGVR, ManagedClusterActionGVR, ManagedClusterViewGVR}
+
+// CheckCRDs uses the discovery client to check that the hub serves every RequiredResources, so a hub missing
+// the ACM CRDs is reported upfront instead of by "no matches for kind" errors in the middle of an operation
+// returns:			error wrapping ErrCRDsMissing and listing the missing resources
+func (c Client) CheckCRDs(ctx context.Context) error {
+	if c.DiscoveryClient == nil {
+		return fmt.Errorf("the client has no discovery client, it must be created with New")
+	}
+
+	var missing []string
+	for _, gvr := range RequiredResources {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		served, err := c.isServed(c.servedGVR(gvr))
+		if err != nil {
+			return err
+		}
+		if !served {
+			missing = append(missing, gvr.GroupResource().String())
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: the hub doesn't serve %s, check ACM is installed", ErrCRDsMissing, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// isServed tells whether the discovery client lists the resource of gvr in its group version
+// returns:			bool, error when the hub couldn't be asked
+func (c Client) isServed(gvr schema.GroupVersionResource) (bool, error) {
+	resources, err := c.DiscoveryClient.ServerResourcesForGroupVersion(gvr.GroupVersion().String())
+	if errors.IsNotFound(err) || goerrors.Is(err, memory.ErrCacheNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("couldn't discover %s on the hub: %w", gvr.GroupVersion(), err)
+	}
+	for _, r := range resources.APIResources {
+		if r.Name == gvr.Resource {
+			return true, nil
+		}
+	}
+	return false, nil
+}
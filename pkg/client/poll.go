@@ -0,0 +1,125 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// DefaultPollJitter is the jitter of the polls when the status options are built with DefaultCheckStatusOptions
+const DefaultPollJitter = 0.2
+
+// pollTicker delivers ticks on C every opts.Interval, moved at random by up to opts.Jitter of it,
+// so the clients polling many spokes at once don't hit the hub in lockstep
+type pollTicker struct {
+	C    <-chan time.Time
+	stop chan struct{}
+}
+
+// newPollTicker starts a pollTicker, it must be stopped with Stop
+// returns:			*pollTicker
+func newPollTicker(opts CheckStatusOptions) *pollTicker {
+	c := make(chan time.Time, 1)
+	t := &pollTicker{C: c, stop: make(chan struct{})}
+
+	go func() {
+		for {
+			timer := time.NewTimer(opts.nextInterval())
+			select {
+			case <-t.stop:
+				timer.Stop()
+				return
+			case now := <-timer.C:
+				// like time.Ticker, drop the tick when the previous one wasn't received yet
+				select {
+				case c <- now:
+				default:
+				}
+			}
+		}
+	}()
+	return t
+}
+
+// Stop stops the ticker, no tick is delivered afterwards
+func (t *pollTicker) Stop() {
+	close(t.stop)
+}
+
+// nextInterval returns Interval moved at random by up to Jitter of it
+// returns:			time.Duration
+func (o CheckStatusOptions) nextInterval() time.Duration {
+	if o.Jitter <= 0 {
+		return o.Interval
+	}
+	jitter := o.Jitter
+	if jitter > 1 {
+		jitter = 1
+	}
+	delta := time.Duration((rand.Float64()*2 - 1) * jitter * float64(o.Interval))
+	if o.Interval+delta <= 0 {
+		return o.Interval
+	}
+	return o.Interval + delta
+}
+
+// pollUntil calls check on every c.StatusOptions.Interval until it reports done or fails,
+// giving up after c.StatusOptions.Timeout. A transient API error returned by check is retried following
+// the retry policy of the client, the calls made by check with the context it's given aren't retried on their own
+// returns:			error, wrapping timeoutErr when check isn't done in time
+func (c Client) pollUntil(ctx context.Context, timeoutErr error, check func(ctx context.Context) (bool, error)) error {
+	opts := c.StatusOptions
+	defaults := DefaultCheckStatusOptions()
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaults.Timeout
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = defaults.Interval
+	}
+
+	policy := c.retryPolicy()
+	checkCtx := context.WithValue(ctx, singleAttemptKey{}, true)
+	ticker := newPollTicker(opts)
+	defer ticker.Stop()
+	timeout := time.After(opts.Timeout)
+
+	// failures counts the checks failed in a row with a transient error
+	failures := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case <-timeout:
+			return fmt.Errorf("%w after %s", timeoutErr, opts.Timeout)
+
+		case <-ticker.C:
+			done, err := check(checkCtx)
+			if err == nil {
+				failures = 0
+				if done {
+					return nil
+				}
+				continue
+			}
+			if !isRetryable(err) {
+				return err
+			}
+			failures++
+			wait, ok := policy.NextDelay(failures)
+			if !ok {
+				return err
+			}
+			c.logger().Debugf("retryable error on check %d, retrying in %s: %s", failures, wait, err)
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-timeout:
+				return fmt.Errorf("%w after %s, last error: %s", timeoutErr, opts.Timeout, err)
+			case <-time.After(wait):
+			}
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextInterval(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		jitter float64
+		min    time.Duration
+		max    time.Duration
+	}{
+		{name: "no jitter", min: time.Second, max: time.Second},
+		{name: "default jitter", jitter: DefaultPollJitter, min: 800 * time.Millisecond, max: 1200 * time.Millisecond},
+		{name: "jitter capped to the interval", jitter: 3, min: time.Nanosecond, max: 2 * time.Second},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := CheckStatusOptions{Interval: time.Second, Jitter: tc.jitter}
+			for i := 0; i < 50; i++ {
+				if got := opts.nextInterval(); got < tc.min || got > tc.max {
+					t.Fatalf("got %s, want between %s and %s", got, tc.min, tc.max)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ProgressAnnotation is the annotation the backup job of backup-image updates on itself to report its progress
+const ProgressAnnotation = "sno-upgrade-recovery/progress"
+
+// ProgressUpdate is a change of the job watched by a managedclusterview, as seen by StreamProgress
+type ProgressUpdate struct {
+	// Progress is the ProgressAnnotation of the job, empty when the job doesn't report it
+	Progress string
+	// Job is the completion details of the job
+	Job JobResult
+	// Result is the job as returned by the spoke
+	Result map[string]interface{}
+}
+
+// StreamProgress polls the managedclusterview of the backup job of clusterName on every c.StatusOptions.Interval
+// and calls fn each time the job returned by the spoke changes, until the job completes or fails or ctx is done.
+// Unlike JobStatus no timeout is applied, ctx bounds the streaming
+// returns:			error, wrapping ErrJobFailed when the job failed
+func (c Client) StreamProgress(ctx context.Context, clusterName string, fn func(update ProgressUpdate)) error {
+	views := c.templates().ViewCreate
+
+	opts := c.StatusOptions
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultCheckStatusOptions().Interval
+	}
+	ticker := newPollTicker(opts)
+	defer ticker.Stop()
+
+	lastVersion := ""
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case <-ticker.C:
+			view, err := c.getStatusView(ctx, MCV, clusterName, views)
+			if err != nil {
+				c.logger().Debugf("couldn't get the managedclusterview of cluster %s: %s", clusterName, err)
+				continue
+			}
+			if err := jobFailure(view, clusterName); err != nil {
+				return err
+			}
+
+			result, found, err := unstructured.NestedMap(view.Object, "status", "result")
+			if err != nil || !found {
+				continue
+			}
+			version, _, _ := unstructured.NestedString(result, "metadata", "resourceVersion")
+			if version == lastVersion {
+				continue
+			}
+			lastVersion = version
+
+			update := ProgressUpdate{Job: DecodeJobResult(result), Result: result}
+			update.Progress, _, _ = unstructured.NestedString(result, "metadata", "annotations", ProgressAnnotation)
+			fn(update)
+
+			if update.Job.Succeeded {
+				return nil
+			}
+		}
+	}
+}
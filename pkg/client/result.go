@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Phases of a backup run, as reported in RunResult.Phase and to Client.OnPhase.
+// PhaseNamespaceAccepted, PhaseRBACAccepted and PhaseJobAccepted are entered once the spoke executed the
+// managedclusteraction creating the namespace, the role bindings or the job, they don't tell the created
+// objects are still there. A namespace or role binding action failing on the spoke, e.g. because a previous
+// run left the object, doesn't enter its phase
+const (
+	PhaseSpokeCheck        = "SpokeCheck"
+	PhaseLaunch            = "Launch"
+	PhaseNamespaceAccepted = "NamespaceAccepted"
+	PhaseRBACAccepted      = "RBACAccepted"
+	PhaseJobAccepted       = "JobAccepted"
+	PhaseJobLaunched       = "JobLaunched"
+	PhaseJobCompleted      = "JobCompleted"
+	PhaseCleanup           = "Cleanup"
+	PhaseDone              = "Done"
+)
+
+// RunResult is the outcome of a backup run on a spoke, it can be marshalled to JSON
+type RunResult struct {
+	// Cluster is the name of the spoke
+	Cluster string `json:"cluster"`
+	// RunID labels the resources created by the run and is set on its log lines
+	RunID string `json:"runID"`
+	// Phase is the last phase reached, the one that failed when Succeeded is false
+	Phase string `json:"phase"`
+	// Succeeded is true once the job reported the Complete condition and the run finished
+	Succeeded bool `json:"succeeded"`
+	// Message is the error of a failed run
+	Message string `json:"message,omitempty"`
+	// DurationSeconds is the time taken by the run
+	DurationSeconds float64 `json:"durationSeconds"`
+	// Output is the status.result payload of the view, i.e. the job as seen on the spoke
+	Output map[string]interface{} `json:"output,omitempty"`
+	// Job is the completion details decoded from Output
+	Job JobResult `json:"jobResult"`
+}
+
+// finish records the duration and the error of the run started at start
+func (r *RunResult) finish(start time.Time, err error) {
+	r.DurationSeconds = time.Since(start).Seconds()
+	r.Succeeded = err == nil
+	if err != nil {
+		r.Message = err.Error()
+		return
+	}
+	r.Phase = PhaseDone
+}
+
+// enterPhase records phase as the current phase of res and reports it to c.OnPhase
+func (c Client) enterPhase(res *RunResult, clusterName string, phase string) {
+	res.Phase = phase
+	c.notifyPhase(clusterName, phase)
+}
+
+// notifyPhase reports phase to c.OnPhase when it's set
+func (c Client) notifyPhase(clusterName string, phase string) {
+	if c.OnPhase != nil {
+		c.OnPhase(phase, clusterName)
+	}
+}
+
+// actionPhase returns the phase entered once the spoke executed the managedclusteraction obj, empty when it doesn't end one
+// returns:			string
+func actionPhase(obj *unstructured.Unstructured) string {
+	kind, _, _ := unstructured.NestedString(obj.Object, "spec", "kube", "template", "kind")
+	switch kind {
+	case "Namespace":
+		return PhaseNamespaceAccepted
+	case "RoleBinding", "ClusterRoleBinding":
+		return PhaseRBACAccepted
+	case "Job":
+		return PhaseJobAccepted
+	}
+	return ""
+}
+
+// viewResult gets the status.result payload of the first managedclusterview rendered from views
+// returns:			map[string]interface{}, error
+func (c Client) viewResult(ctx context.Context, clusterName string, views []ResourceTemplate) (map[string]interface{}, error) {
+	view, err := c.getStatusView(ctx, MCV, clusterName, views)
+	if err != nil {
+		return nil, err
+	}
+	result, _, err := unstructured.NestedMap(view.Object, "status", "result")
+	return result, err
+}
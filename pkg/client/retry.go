@@ -0,0 +1,165 @@
+package client
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+// DefaultOperationTimeout bounds a single call to the kubernetes API
+const DefaultOperationTimeout = 30 * time.Second
+
+// DefaultConnectTimeout bounds the connection to the hub and the reachability check done when the client is built
+const DefaultConnectTimeout = 10 * time.Second
+
+// RetryOptions controls how transient API errors are retried when Client.RetryPolicy isn't set
+//
+// Deprecated: set Client.RetryPolicy instead, RetryOptions is the ExponentialBackoff with jitter
+// returned by its Policy method
+type RetryOptions struct {
+	// Attempts is the maximum number of calls, including the first one
+	Attempts int
+	// BaseDelay is the delay before the first retry, doubled on every following one
+	BaseDelay time.Duration
+}
+
+// DefaultRetryOptions returns the retry options matching DefaultRetryPolicy
+//
+// Deprecated: use DefaultRetryPolicy
+// returns:			RetryOptions
+func DefaultRetryOptions() RetryOptions {
+	return RetryOptions{
+		Attempts:  5,
+		BaseDelay: 500 * time.Millisecond,
+	}
+}
+
+// Policy returns the exponential backoff with jitter described by o, the unset fields taking
+// the values of DefaultRetryOptions
+// returns:			RetryPolicy
+func (o RetryOptions) Policy() RetryPolicy {
+	defaults := DefaultRetryOptions()
+	if o.Attempts <= 0 {
+		o.Attempts = defaults.Attempts
+	}
+	if o.BaseDelay <= 0 {
+		o.BaseDelay = defaults.BaseDelay
+	}
+	return ExponentialBackoff{Attempts: o.Attempts, BaseDelay: o.BaseDelay, Jitter: true}
+}
+
+// DefaultRetryPolicy returns the retry policy used by New
+// returns:			RetryPolicy
+func DefaultRetryPolicy() RetryPolicy {
+	return DefaultRetryOptions().Policy()
+}
+
+// isRetryable tells whether err is a transient API error worth retrying
+// returns:			bool
+func isRetryable(err error) bool {
+	return errors.IsServerTimeout(err) || errors.IsTooManyRequests(err) || errors.IsInternalError(err)
+}
+
+// operationContext derives the context of a single API call from ctx, bounded by c.OperationTimeout
+// returns:			context.Context, context.CancelFunc
+func (c Client) operationContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	timeout := c.OperationTimeout
+	if timeout <= 0 {
+		timeout = DefaultOperationTimeout
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// RetryPolicy decides how long to wait before retrying a call that failed with a transient API error
+type RetryPolicy interface {
+	// NextDelay returns the delay before the next call after attempt calls failed, attempt starting at 1,
+	// and false when no more call must be made
+	NextDelay(attempt int) (time.Duration, bool)
+}
+
+// ConstantBackoff retries up to Attempts calls, waiting Delay between two calls
+type ConstantBackoff struct {
+	Attempts int
+	Delay    time.Duration
+}
+
+// NextDelay implements RetryPolicy
+// returns:			time.Duration, bool
+func (b ConstantBackoff) NextDelay(attempt int) (time.Duration, bool) {
+	if attempt >= b.Attempts {
+		return 0, false
+	}
+	return b.Delay, true
+}
+
+// ExponentialBackoff retries up to Attempts calls, waiting BaseDelay before the first retry and doubling it
+// on every following one. The delay is capped to MaxDelay when it's set, and Jitter adds up to half of it at random
+type ExponentialBackoff struct {
+	Attempts  int
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	Jitter    bool
+}
+
+// NextDelay implements RetryPolicy
+// returns:			time.Duration, bool
+func (b ExponentialBackoff) NextDelay(attempt int) (time.Duration, bool) {
+	if attempt >= b.Attempts {
+		return 0, false
+	}
+	delay := b.BaseDelay
+	for i := 1; i < attempt && (b.MaxDelay <= 0 || delay < b.MaxDelay); i++ {
+		delay *= 2
+	}
+	if b.MaxDelay > 0 && delay > b.MaxDelay {
+		delay = b.MaxDelay
+	}
+	if b.Jitter {
+		delay += time.Duration(rand.Int63n(int64(delay)/2 + 1))
+	}
+	return delay, true
+}
+
+// retryPolicy returns c.RetryPolicy, or the policy of the deprecated c.RetryOptions when it isn't set
+// returns:			RetryPolicy
+func (c Client) retryPolicy() RetryPolicy {
+	if c.RetryPolicy != nil {
+		return c.RetryPolicy
+	}
+	return c.RetryOptions.Policy()
+}
+
+// singleAttemptKey marks the context of the checks run by pollUntil, their calls aren't retried by withRetry
+// since pollUntil applies the retry policy to the whole check
+type singleAttemptKey struct{}
+
+// withRetry calls fn until it succeeds, fails with a non retryable error or the retry policy of the client
+// gives up, waiting the delay returned by the policy between two calls
+// returns:			error
+func (c Client) withRetry(ctx context.Context, fn func() error) error {
+	if ctx.Value(singleAttemptKey{}) != nil {
+		return fn()
+	}
+	policy := c.retryPolicy()
+
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || !isRetryable(err) {
+			return err
+		}
+		wait, ok := policy.NextDelay(attempt)
+		if !ok {
+			return err
+		}
+
+		c.logger().Debugf("retryable error on attempt %d, retrying in %s: %s", attempt, wait, err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package client
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestExponentialBackoffNextDelay(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		backoff ExponentialBackoff
+		attempt int
+		want    time.Duration
+		wantOK  bool
+	}{
+		{name: "first retry", backoff: ExponentialBackoff{Attempts: 5, BaseDelay: time.Second}, attempt: 1, want: time.Second, wantOK: true},
+		{name: "doubled", backoff: ExponentialBackoff{Attempts: 5, BaseDelay: time.Second}, attempt: 3, want: 4 * time.Second, wantOK: true},
+		{name: "capped", backoff: ExponentialBackoff{Attempts: 10, BaseDelay: time.Second, MaxDelay: 5 * time.Second}, attempt: 8, want: 5 * time.Second, wantOK: true},
+		{name: "uncapped", backoff: ExponentialBackoff{Attempts: 10, BaseDelay: time.Second}, attempt: 8, want: 128 * time.Second, wantOK: true},
+		{name: "last attempt", backoff: ExponentialBackoff{Attempts: 3, BaseDelay: time.Second}, attempt: 3, wantOK: false},
+		{name: "no attempt", backoff: ExponentialBackoff{BaseDelay: time.Second}, attempt: 1, wantOK: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := tc.backoff.NextDelay(tc.attempt)
+			if got != tc.want || ok != tc.wantOK {
+				t.Errorf("got %s, %t, want %s, %t", got, ok, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestExponentialBackoffJitter(t *testing.T) {
+	backoff := ExponentialBackoff{Attempts: 5, BaseDelay: time.Second, MaxDelay: 4 * time.Second, Jitter: true}
+	for attempt, base := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second} {
+		for i := 0; i < 20; i++ {
+			got, ok := backoff.NextDelay(attempt + 1)
+			if !ok || got < base || got > base+base/2 {
+				t.Fatalf("attempt %d: got %s, %t, want between %s and %s", attempt+1, got, ok, base, base+base/2)
+			}
+		}
+	}
+}
+
+func TestRetryOptionsPolicy(t *testing.T) {
+	c := Client{RetryOptions: RetryOptions{Attempts: 2, BaseDelay: time.Second}}
+	want := ExponentialBackoff{Attempts: 2, BaseDelay: time.Second, Jitter: true}
+	if got := c.retryPolicy(); got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	c.RetryPolicy = ConstantBackoff{Attempts: 3}
+	if got := c.retryPolicy(); got != c.RetryPolicy {
+		t.Errorf("got %+v, want the RetryPolicy of the client", got)
+	}
+}
+
+func TestPollUntilRetryPolicy(t *testing.T) {
+	transient := errors.NewTooManyRequests("throttled", 0)
+	forbidden := errors.NewForbidden(schema.GroupResource{Resource: "managedclusterviews"}, "view", nil)
+
+	for _, tc := range []struct {
+		name      string
+		failures  int
+		err       error
+		wantCalls int
+		wantErr   bool
+	}{
+		{name: "transient errors retried", failures: 2, err: transient, wantCalls: 3},
+		{name: "policy gives up", failures: 10, err: transient, wantCalls: 3, wantErr: true},
+		{name: "other errors not retried", failures: 10, err: forbidden, wantCalls: 1, wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := log.New()
+			logger.SetOutput(ioutil.Discard)
+			c := Client{
+				Logger:        logger,
+				StatusOptions: CheckStatusOptions{Timeout: 5 * time.Second, Interval: time.Millisecond},
+				RetryPolicy:   ConstantBackoff{Attempts: 3, Delay: time.Millisecond},
+			}
+
+			calls := 0
+			err := c.pollUntil(context.Background(), ErrViewTimeout, func(ctx context.Context) (bool, error) {
+				calls++
+				if calls <= tc.failures {
+					return false, tc.err
+				}
+				return true, nil
+			})
+			if (err != nil) != tc.wantErr {
+				t.Errorf("got error %v, want error %t", err, tc.wantErr)
+			}
+			if calls != tc.wantCalls {
+				t.Errorf("got %d checks, want %d", calls, tc.wantCalls)
+			}
+		})
+	}
+}
+
+func TestPollUntilChecksAreNotRetried(t *testing.T) {
+	c := Client{
+		StatusOptions: CheckStatusOptions{Timeout: 5 * time.Second, Interval: time.Millisecond},
+		RetryPolicy:   ConstantBackoff{Attempts: 1},
+	}
+
+	calls := 0
+	_ = c.pollUntil(context.Background(), ErrViewTimeout, func(ctx context.Context) (bool, error) {
+		return false, c.withRetry(ctx, func() error {
+			calls++
+			return errors.NewTooManyRequests("throttled", 0)
+		})
+	})
+	if calls != 1 {
+		t.Errorf("got %d calls, want a single call per check", calls)
+	}
+}
+
+func TestWithRetry(t *testing.T) {
+	transient := errors.NewTooManyRequests("throttled", 0)
+	exists := errors.NewAlreadyExists(schema.GroupResource{Resource: "managedclusteractions"}, "action")
+
+	for _, tc := range []struct {
+		name      string
+		failures  int
+		err       error
+		wantCalls int
+		wantErr   error
+	}{
+		{name: "no error", wantCalls: 1},
+		{name: "transient errors retried", failures: 2, err: transient, wantCalls: 3},
+		{name: "attempts exhausted", failures: 10, err: transient, wantCalls: 4, wantErr: transient},
+		{name: "already exists not retried", failures: 10, err: exists, wantCalls: 1, wantErr: exists},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			logger := log.New()
+			logger.SetOutput(ioutil.Discard)
+			c := Client{Logger: logger, RetryPolicy: ExponentialBackoff{Attempts: 4, BaseDelay: time.Millisecond}}
+
+			calls := 0
+			err := c.withRetry(context.Background(), func() error {
+				calls++
+				if calls <= tc.failures {
+					return tc.err
+				}
+				return nil
+			})
+			if err != tc.wantErr {
+				t.Errorf("got error %v, want %v", err, tc.wantErr)
+			}
+			if calls != tc.wantCalls {
+				t.Errorf("got %d calls, want %d", calls, tc.wantCalls)
+			}
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package client
 
+import "text/template"
+
 const commonTemplates string = `
 {{ define "actionGVK" }}
 apiVersion: action.open-cluster-management.io/v1beta1
@@ -9,12 +11,80 @@ kind: ManagedClusterAction
 apiVersion: view.open-cluster-management.io/v1beta1
 kind: ManagedClusterView
 {{ end }}
+{{ define "resources" }}
+                {{- if or .CPURequest .MemRequest .CPULimit .MemLimit }}
+                resources:
+                  {{- if or .CPURequest .MemRequest }}
+                  requests:
+                    {{- if .CPURequest }}
+                    cpu: {{ .CPURequest | quote }}
+                    {{- end }}
+                    {{- if .MemRequest }}
+                    memory: {{ .MemRequest | quote }}
+                    {{- end }}
+                  {{- end }}
+                  {{- if or .CPULimit .MemLimit }}
+                  limits:
+                    {{- if .CPULimit }}
+                    cpu: {{ .CPULimit | quote }}
+                    {{- end }}
+                    {{- if .MemLimit }}
+                    memory: {{ .MemLimit | quote }}
+                    {{- end }}
+                  {{- end }}
+                {{- end }}
+{{- end }}
+{{ define "volumeMounts" }}
+                volumeMounts:
+                  -
+                    mountPath: /host
+                    name: backup
+                  {{- range .VolumeMounts }}
+                  - {{ toJson . }}
+                  {{- end }}
+{{- end }}
+{{ define "volumes" }}
+            volumes:
+              -
+                hostPath:
+                  path: /
+                  type: Directory
+                name: backup
+              {{- range .Volumes }}
+              - {{ toJson . }}
+              {{- end }}
+{{- end }}
+{{ define "scheduling" }}
+            {{- if .NodeSelector }}
+            nodeSelector: {{ toJson .NodeSelector }}
+            {{- end }}
+            {{- if .Tolerations }}
+            tolerations:
+              {{- range .Tolerations }}
+              - {{ toJson . }}
+              {{- end }}
+            {{- end }}
+{{- end }}
+{{ define "scanInterval" }}
+    {{- if .ScanInterval }}
+    updateIntervalSeconds: {{ .ScanInterval }}
+    {{- end }}
+{{- end }}
 {{ define "metadata"}}
 metadata:
   name: {{ .ResourceName }}
   namespace: {{ .ClusterName }}
+  labels:
+    app.kubernetes.io/managed-by: {{ .ManagedBy | quote }}
+    {{- if .RunID }}
+    sno-upgrade-recovery/run-id: {{ .RunID | quote }}
+    {{- end }}
 {{ end }}
 `
+
+// commonTemplate is commonTemplates parsed once, every template body is parsed in a clone of it
+var commonTemplate = template.Must(template.New("common").Funcs(templateFuncs).Parse(commonTemplates))
+
 const mngClusterActCreateNS = `
 {{ template "actionGVK"}}
 {{ template "metadata" . }}
@@ -26,7 +96,7 @@ spec:
       apiVersion: v1
       kind: Namespace
       metadata: 
-        name: backupresource
+        name: {{ .Namespace }}
 `
 const mngClusterActCreateSA = `
 {{ template "actionGVK"}}
@@ -39,8 +109,8 @@ spec:
       apiVersion: v1
       kind: ServiceAccount
       metadata:
-        name: backupresource
-        namespace: backupresource
+        name: {{ .ServiceAccountName }}
+        namespace: {{ .Namespace }}
 `
 const mngClusterActCreateRB = `
 {{ template "actionGVK"}}
@@ -57,11 +127,11 @@ spec:
       roleRef:
         apiGroup: rbac.authorization.k8s.io
         kind: ClusterRole
-        name: cluster-admin
+        name: {{ .ClusterRoleName }}
       subjects:
         - kind: ServiceAccount
-          name: backupresource
-          namespace: backupresource
+          name: {{ .ServiceAccountName }}
+          namespace: {{ .Namespace }}
 `
 const mngClusterActCreateJob string = `
 {{ template "actionGVK"}}
@@ -69,7 +139,7 @@ const mngClusterActCreateJob string = `
 spec:
   actionType: Create
   kube:
-    namespace: backupresource
+    namespace: {{ .Namespace }}
     resource: job
     template:
       apiVersion: batch/v1
@@ -78,33 +148,66 @@ spec:
         name: backupresource
       spec:
         backoffLimit: 0
+        {{- if .TTLSeconds }}
+        ttlSecondsAfterFinished: {{ .TTLSeconds }}
+        {{- end }}
         template:
           spec:
             containers:
               -
+                {{- if .Command }}
+                command:
+                  {{- range .Command }}
+                  - {{ toJson . }}
+                  {{- end }}
+                {{- end }}
                 args:
+                  {{- if .Args }}
+                  {{- range .Args }}
+                  - {{ toJson . }}
+                  {{- end }}
+                  {{- else }}
                   - launchBackup
                   - "--BackupPath"
-                  - /var/recovery
-                image: 2620-52-0-1302--1db3.sslip.io:5000/olm/openshift-ai-image-backup:latest
+                  - {{ .RecoveryPath }}
+                  {{- end }}
+                env:
+                  - name: JOB_NAME
+                    valueFrom:
+                      fieldRef:
+                        fieldPath: metadata.labels['job-name']
+                  - name: JOB_NAMESPACE
+                    valueFrom:
+                      fieldRef:
+                        fieldPath: metadata.namespace
+                image: {{ .Image }}
                 name: container-image
+                {{- template "resources" . }}
                 securityContext:
                   privileged: true
                   runAsUser: 0
                 tty: true
-                volumeMounts:
-                  -
-                    mountPath: /host
-                    name: backup
+                {{- template "volumeMounts" . }}
             restartPolicy: Never
             hostNetwork: true
-            serviceAccountName: backupresource
-            volumes:
+            serviceAccountName: {{ .ServiceAccountName }}
+            {{- template "scheduling" . }}
+            {{- if .ImagePullSecret }}
+            imagePullSecrets:
               -
-                hostPath:
-                  path: /
-                  type: Directory
-                name: backup
+                name: {{ .ImagePullSecret }}
+            {{- end }}
+            {{- template "volumes" . }}
+`
+const mngClusterActDeleteJob string = `
+{{ template "actionGVK"}}
+{{ template "metadata" . }}
+spec:
+  actionType: Delete
+  kube:
+    name: backupresource
+    namespace: {{ .Namespace }}
+    resource: job
 `
 const mngClusterActDeleteNS string = `
 {{ template "actionGVK"}}
@@ -112,7 +215,7 @@ const mngClusterActDeleteNS string = `
 spec: 
   actionType: Delete
   kube: 
-    name: backupresource
+    name: {{ .Namespace }}
     resource: namespace
 `
 const mngClusterViewJob string = `
@@ -120,7 +223,33 @@ const mngClusterViewJob string = `
 {{ template "metadata" . }}
 spec:
   scope:
+    {{- template "scanInterval" . }}
     resource: jobs
     name: backupresource
-    namespace: backupresource
+    namespace: {{ .Namespace }}
+`
+const mngClusterViewNamespace string = `
+{{ template "viewGVK"}}
+{{ template "metadata" . }}
+spec:
+  scope:
+    {{- template "scanInterval" . }}
+    resource: namespaces
+    name: {{ .Namespace }}
+`
+const mngClusterViewResource string = `
+{{ template "viewGVK"}}
+{{ template "metadata" . }}
+spec:
+  scope:
+    {{- template "scanInterval" . }}
+    {{- if .Scope.Group }}
+    apiGroup: {{ .Scope.Group }}
+    {{- end }}
+    version: {{ .Scope.Version }}
+    kind: {{ .Scope.Kind }}
+    name: {{ .Scope.Name }}
+    {{- if .Scope.Namespace }}
+    namespace: {{ .Scope.Namespace }}
+    {{- end }}
 `
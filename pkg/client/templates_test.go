@@ -0,0 +1,142 @@
+package client
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/serializer/yaml"
+)
+
+func TestRenderLabelsKeepStrings(t *testing.T) {
+	c := newClient([]string{"spoke"}, DefaultBackupPath, "")
+
+	for _, tc := range []struct {
+		name      string
+		runID     string
+		managedBy string
+	}{
+		{name: "hex run id", runID: "0a1b2c3d", managedBy: DefaultManagedBy},
+		{name: "numeric run id", runID: "01234567", managedBy: DefaultManagedBy},
+		{name: "float like run id", runID: "123456e7", managedBy: DefaultManagedBy},
+		{name: "numeric managed by", runID: "0a1b2c3d", managedBy: "1234"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c.RunID, c.ManagedBy = tc.runID, tc.managedBy
+			templates := c.templates()
+			for _, set := range [][]ResourceTemplate{templates.ActionCreate, templates.ViewCreate, templates.JobDelete, AbortTemplates, NamespaceViewTemplates} {
+				for _, item := range set {
+					w, err := c.RenderYamlTemplate(item.ResourceName, item.Template, c.templateData("spoke"))
+					if err != nil {
+						t.Fatal(err)
+					}
+					obj := &unstructured.Unstructured{}
+					if _, _, err := yaml.NewDecodingSerializer(unstructured.UnstructuredJSONScheme).Decode(w.Bytes(), nil, obj); err != nil {
+						t.Fatalf("%s: %s", item.ResourceName, err)
+					}
+					labels := obj.GetLabels()
+					if labels[RunIDLabel] != tc.runID || labels[ManagedByLabel] != tc.managedBy {
+						t.Errorf("%s: got labels %v, want run id %q managed by %q", item.ResourceName, labels, tc.runID, tc.managedBy)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRenderBackupJob(t *testing.T) {
+	jobSpec := []string{"spec", "kube", "template", "spec", "template", "spec"}
+
+	for _, tc := range []struct {
+		name  string
+		setup func(c *Client)
+		// want maps a path under the pod spec of the job to its expected value
+		want map[string]interface{}
+	}{
+		{
+			name:  "defaults",
+			setup: func(c *Client) {},
+			want: map[string]interface{}{
+				"containers.0.image":              DefaultImage,
+				"containers.0.args":               []interface{}{"launchBackup", "--BackupPath", DefaultBackupPath},
+				"containers.0.env.0.name":         "JOB_NAME",
+				"serviceAccountName":              DefaultServiceAccountName,
+				"containers.0.resources.requests": map[string]interface{}{"cpu": DefaultCPURequest, "memory": DefaultMemRequest},
+				"imagePullSecrets":                nil,
+			},
+		},
+		{
+			name: "image and pull secret",
+			setup: func(c *Client) {
+				c.Image = "quay.io/example/backup:1.0"
+				c.ImagePullSecret = "pull-secret"
+			},
+			want: map[string]interface{}{
+				"containers.0.image":      "quay.io/example/backup:1.0",
+				"imagePullSecrets.0.name": "pull-secret",
+			},
+		},
+		{
+			name: "custom command and args",
+			setup: func(c *Client) {
+				c.Command = []string{"/bin/sh", "-c"}
+				c.Args = []string{"echo \"quoted: value\" && exit 0"}
+			},
+			want: map[string]interface{}{
+				"containers.0.command": []interface{}{"/bin/sh", "-c"},
+				"containers.0.args":    []interface{}{"echo \"quoted: value\" && exit 0"},
+			},
+		},
+		{
+			name: "scheduling",
+			setup: func(c *Client) {
+				c.NodeSelector = map[string]string{"node-role.kubernetes.io/master": ""}
+				c.Tolerations = []corev1.Toleration{{Key: "node-role.kubernetes.io/master", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule}}
+			},
+			want: map[string]interface{}{
+				"nodeSelector":           map[string]interface{}{"node-role.kubernetes.io/master": ""},
+				"tolerations.0.key":      "node-role.kubernetes.io/master",
+				"tolerations.0.operator": "Exists",
+				"tolerations.0.effect":   "NoSchedule",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newClient([]string{"spoke"}, DefaultBackupPath, "")
+			tc.setup(&c)
+
+			obj := renderTemplate(t, c, "backup-create-job")
+			podSpec, _, err := unstructured.NestedMap(obj.Object, jobSpec...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for path, want := range tc.want {
+				if got := lookupPath(podSpec, path); !reflect.DeepEqual(got, want) {
+					t.Errorf("%s: got %#v, want %#v", path, got, want)
+				}
+			}
+		})
+	}
+}
+
+// lookupPath returns the value at the dot separated path of obj, numbers indexing the slices, nil when it's missing
+func lookupPath(obj interface{}, path string) interface{} {
+	for _, key := range strings.Split(path, ".") {
+		switch value := obj.(type) {
+		case map[string]interface{}:
+			obj = value[key]
+		case []interface{}:
+			i, err := strconv.Atoi(key)
+			if err != nil || i >= len(value) {
+				return nil
+			}
+			obj = value[i]
+		default:
+			return nil
+		}
+	}
+	return obj
+}
@@ -0,0 +1,36 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// validateRendered checks that a rendered template sets the fields required to create it,
+// so a template regression is reported before anything is sent to the cluster
+// returns:			error naming the template and the missing field
+func validateRendered(resourceName string, rendered []byte) error {
+	data, err := utilyaml.ToJSON(rendered)
+	if err != nil {
+		return fmt.Errorf("template %s: invalid yaml: %w", resourceName, err)
+	}
+
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return fmt.Errorf("template %s: invalid object: %w", resourceName, err)
+	}
+
+	for _, field := range [][]string{{"apiVersion"}, {"kind"}, {"metadata", "name"}} {
+		value, found, err := unstructured.NestedString(obj, field...)
+		if err != nil {
+			return fmt.Errorf("template %s: field %s: %w", resourceName, strings.Join(field, "."), err)
+		}
+		if !found || value == "" {
+			return fmt.Errorf("template %s: required field %s is not set", resourceName, strings.Join(field, "."))
+		}
+	}
+	return nil
+}
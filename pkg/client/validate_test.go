@@ -0,0 +1,52 @@
+package client
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateRendered(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		rendered string
+		wantErr  string
+	}{
+		{
+			name:     "complete",
+			rendered: "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: backupresource\n",
+		},
+		{
+			name:     "missing kind",
+			rendered: "apiVersion: v1\nmetadata:\n  name: backupresource\n",
+			wantErr:  "required field kind is not set",
+		},
+		{
+			name:     "empty name",
+			rendered: "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: \"\"\n",
+			wantErr:  "required field metadata.name is not set",
+		},
+		{
+			name:     "name not a string",
+			rendered: "apiVersion: v1\nkind: Namespace\nmetadata:\n  name:\n    nested: true\n",
+			wantErr:  "field metadata.name",
+		},
+		{
+			name:     "invalid yaml",
+			rendered: "apiVersion: v1\nkind: [Namespace\n",
+			wantErr:  "invalid yaml",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateRendered("test", []byte(tc.rendered))
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("got %s, want no error", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("got %v, want an error containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+// ResourceReport lists the resources of the templates missing or different on the hub
+type ResourceReport struct {
+	// Missing are the resources not found on the hub, as kind/name
+	Missing []string
+	// Mismatched describes the resources found with an unexpected name, namespace or owner
+	Mismatched []string
+}
+
+// OK tells whether every expected resource was found as expected
+// returns:			bool
+func (r ResourceReport) OK() bool {
+	return len(r.Missing) == 0 && len(r.Mismatched) == 0
+}
+
+// VerifyResources checks that the managedclusteractions and managedclusterviews of the action and view
+// templates exist on the hub for clusterName, with the expected name, namespace and managed-by label
+// returns:			ResourceReport, error when the resources couldn't be read
+func (c Client) VerifyResources(ctx context.Context, clusterName string) (ResourceReport, error) {
+	var report ResourceReport
+	templates := c.templates()
+
+	for _, set := range []struct {
+		resourceType string
+		templates    []ResourceTemplate
+	}{
+		{MCA, templates.ActionCreate},
+		{MCV, templates.ViewCreate},
+	} {
+		for _, item := range set.templates {
+			id := set.resourceType + "/" + item.ResourceName
+
+			objs, err := c.ManageObjects(ctx, clusterName, []ResourceTemplate{item}, set.resourceType, "get")
+			if errors.IsNotFound(err) {
+				report.Missing = append(report.Missing, id)
+				continue
+			}
+			if err != nil {
+				return report, fmt.Errorf("couldn't get %s of cluster %s: %w", id, clusterName, err)
+			}
+
+			obj := objs[0]
+			if obj.GetName() != item.ResourceName || obj.GetNamespace() != clusterName {
+				report.Mismatched = append(report.Mismatched, fmt.Sprintf("%s: found %s/%s, expected %s/%s", id, obj.GetNamespace(), obj.GetName(), clusterName, item.ResourceName))
+				continue
+			}
+			if managedBy := obj.GetLabels()[ManagedByLabel]; managedBy != c.managedBy() {
+				report.Mismatched = append(report.Mismatched, fmt.Sprintf("%s: %s label is %q, expected %q", id, ManagedByLabel, managedBy, c.managedBy()))
+			}
+		}
+	}
+
+	if !report.OK() {
+		c.logger().Infof("resources of cluster %s: missing %v, mismatched %v", clusterName, report.Missing, report.Mismatched)
+	}
+	return report, nil
+}
+
+// LaunchTemplatesByName creates the resources of the action and view templates named in names, e.g. to recreate
+// the ones VerifyResources reported missing, the resources still there are left as they are
+// returns:			error, also when a name matches no template
+func (c Client) LaunchTemplatesByName(ctx context.Context, clusterName string, names []string) error {
+	templates := c.templates()
+
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	var selected []ResourceTemplate
+	for _, item := range append(append([]ResourceTemplate(nil), templates.ActionCreate...), templates.ViewCreate...) {
+		if wanted[item.ResourceName] {
+			selected = append(selected, item)
+			delete(wanted, item.ResourceName)
+		}
+	}
+	if len(wanted) > 0 {
+		unknown := make([]string, 0, len(wanted))
+		for name := range wanted {
+			unknown = append(unknown, name)
+		}
+		sort.Strings(unknown)
+		return fmt.Errorf("no action or view template is named %s", strings.Join(unknown, ", "))
+	}
+
+	return c.LaunchKubernetesObjects(ctx, clusterName, selected)
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// clusterVersionGVK is the kind of the OpenShift cluster version of the spoke
+var clusterVersionGVK = schema.GroupVersionKind{Group: "config.openshift.io", Version: "v1", Kind: "ClusterVersion"}
+
+// GetSpokeClusterVersion reads the OpenShift clusterversion of the spoke through a managedclusterview,
+// which is deleted once read
+// returns:			desired version, latest completed version (empty when none completed), error
+func (c Client) GetSpokeClusterVersion(ctx context.Context, clusterName string) (string, string, error) {
+	viewName, err := c.CreateView(ctx, clusterName, clusterVersionGVK, "version", "")
+	if err != nil {
+		return "", "", fmt.Errorf("couldn't create the clusterversion view in the %s cluster err: %w", clusterName, err)
+	}
+	defer func() {
+		if err := c.DeleteView(context.Background(), clusterName, viewName); err != nil {
+			c.logger().Errorf("couldn't delete the clusterversion view in the %s cluster err: %s", clusterName, err)
+		}
+	}()
+
+	var result map[string]interface{}
+	err = c.pollUntil(ctx, fmt.Errorf("%w: clusterversion of cluster %s", ErrViewTimeout, clusterName), func(ctx context.Context) (bool, error) {
+		res, err := c.GetViewResult(ctx, clusterName, viewName)
+		if isRetryable(err) {
+			return false, err
+		}
+		if err != nil {
+			c.logger().Debugf("couldn't get the clusterversion view of cluster %s: %s", clusterName, err)
+			return false, nil
+		}
+		result = res.Result
+		return len(result) > 0, nil
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	desired, _, err := unstructured.NestedString(result, "status", "desired", "version")
+	if err != nil {
+		return "", "", err
+	}
+
+	history, _, err := unstructured.NestedSlice(result, "status", "history")
+	if err != nil {
+		return "", "", err
+	}
+	// history is ordered from the most recent update
+	for _, h := range history {
+		entry, ok := h.(map[string]interface{})
+		if !ok || entry["state"] != "Completed" {
+			continue
+		}
+		completed, _ := entry["version"].(string)
+		return desired, completed, nil
+	}
+	return desired, "", nil
+}
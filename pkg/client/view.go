@@ -0,0 +1,337 @@
+package client
+
+import (
+	"context"
+	goerrors "errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// ViewResult is the decoded status of a managedclusterview
+type ViewResult struct {
+	// Processing is true once the view has fetched the watched resource from the spoke
+	Processing bool
+	// Completed is true once the watched job reports the Complete condition
+	Completed bool
+	// Message is the latest message reported by the view or the watched job
+	Message string
+	// Result is the watched resource as returned by the spoke
+	Result map[string]interface{}
+}
+
+// ViewScope is the spoke resource watched by a managedclusterview created with CreateView
+type ViewScope struct {
+	Group     string
+	Version   string
+	Kind      string
+	Name      string
+	Namespace string
+}
+
+// CreateView creates a managedclusterview watching the spoke resource of kind gvk named name in namespace,
+// namespace is empty for cluster scoped resources. The view can then be read with GetViewResult
+// returns:			string (view name), error
+func (c Client) CreateView(ctx context.Context, clusterName string, gvk schema.GroupVersionKind, name string, namespace string) (string, error) {
+	if err := ValidateClusterName(clusterName); err != nil {
+		return "", err
+	}
+	if gvk.Version == "" || gvk.Kind == "" || name == "" {
+		return "", fmt.Errorf("the version, kind and name of the viewed resource must be set")
+	}
+	if c.RESTMapper == nil {
+		return "", fmt.Errorf("the client has no RESTMapper, it must be created with New")
+	}
+
+	viewName := strings.ToLower("view-" + gvk.Kind + "-" + name)
+	if len(viewName) > 63 {
+		viewName = viewName[:63]
+	}
+	viewName = strings.TrimRight(viewName, "-.")
+
+	data := c.templateData(clusterName)
+	data.ResourceName = viewName
+	data.Scope = ViewScope{
+		Group:     gvk.Group,
+		Version:   gvk.Version,
+		Kind:      gvk.Kind,
+		Name:      name,
+		Namespace: namespace,
+	}
+
+	if _, err := c.launchKubernetesObject(ctx, clusterName, ResourceTemplate{viewName, mngClusterViewResource}, data); err != nil {
+		return "", err
+	}
+	return viewName, nil
+}
+
+// EnsureView gets the managedclusterview rendered from view, creating it first when it doesn't exist,
+// so it can be called repeatedly while a backup is retried. No view is returned when c.DryRun or c.ServerDryRun is set
+// returns:			*unstructured.Unstructured, error
+func (c Client) EnsureView(ctx context.Context, clusterName string, view ResourceTemplate) (*unstructured.Unstructured, error) {
+	views, err := c.ManageObjects(ctx, clusterName, []ResourceTemplate{view}, MCV, "get")
+	if err == nil {
+		c.logger().Debugf("managedclusterview %s already exists for cluster %s", view.ResourceName, clusterName)
+		return views[0], nil
+	}
+	if !errors.IsNotFound(err) {
+		return nil, err
+	}
+
+	if err := c.LaunchKubernetesObjects(ctx, clusterName, []ResourceTemplate{view}); err != nil {
+		return nil, err
+	}
+	if c.dryRun() {
+		return nil, nil
+	}
+	views, err = c.ManageObjects(ctx, clusterName, []ResourceTemplate{view}, MCV, "get")
+	if err != nil {
+		return nil, err
+	}
+	return views[0], nil
+}
+
+// DeleteView deletes the managedclusterview named viewName, a view already gone isn't an error
+// returns:			error
+func (c Client) DeleteView(ctx context.Context, clusterName string, viewName string) error {
+	_, err := c.ManageObjects(ctx, clusterName, []ResourceTemplate{{ResourceName: viewName}}, MCV, "delete")
+	if errors.IsNotFound(err) {
+		c.logger().Debugf("managedclusterview %s of cluster %s is already deleted", viewName, clusterName)
+		return nil
+	}
+	return err
+}
+
+// WaitForViewDeleted polls the managedclusterview named viewName until it's gone from the hub, so a view can be
+// recreated once the deleted one finished terminating. timeout overrides c.StatusOptions.Timeout when set
+// returns:			error, wrapping ErrViewTimeout when the view is still there
+func (c Client) WaitForViewDeleted(ctx context.Context, clusterName string, viewName string, timeout time.Duration) error {
+	if timeout > 0 {
+		c.StatusOptions.Timeout = timeout
+	}
+
+	return c.pollUntil(ctx, fmt.Errorf("%w: managedclusterview %s of cluster %s still exists", ErrViewTimeout, viewName, clusterName), func(ctx context.Context) (bool, error) {
+		views, err := c.ManageObjects(ctx, clusterName, []ResourceTemplate{{ResourceName: viewName}}, MCV, "getIfExists")
+		if isRetryable(err) {
+			return false, err
+		}
+		if err != nil {
+			c.logger().Debugf("couldn't get managedclusterview %s of cluster %s: %s", viewName, clusterName, err)
+			return false, nil
+		}
+		return views[0] == nil, nil
+	})
+}
+
+// GetViewResult gets the managedclusterview named viewName and decodes its status
+// returns:			ViewResult, error
+func (c Client) GetViewResult(ctx context.Context, clusterName string, viewName string) (ViewResult, error) {
+	views, err := c.ManageObjects(ctx, clusterName, []ResourceTemplate{{ResourceName: viewName}}, MCV, "get")
+	if err != nil {
+		return ViewResult{}, err
+	}
+	return decodeViewResult(views[0])
+}
+
+// decodeViewResult decodes the conditions and the result of a managedclusterview
+// returns:			ViewResult, error
+func decodeViewResult(view *unstructured.Unstructured) (ViewResult, error) {
+	var res ViewResult
+
+	conditions, _, err := unstructured.NestedSlice(view.Object, "status", "conditions")
+	if err != nil {
+		return res, err
+	}
+	for _, condition := range conditions {
+		cond, ok := condition.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if cond["type"] == "Processing" && cond["status"] == "True" {
+			res.Processing = true
+		}
+		if msg, ok := cond["message"].(string); ok && msg != "" {
+			res.Message = msg
+		}
+	}
+
+	result, _, err := unstructured.NestedMap(view.Object, "status", "result")
+	if err != nil {
+		return res, err
+	}
+	res.Result = result
+
+	jobConditions, _, err := unstructured.NestedSlice(result, "status", "conditions")
+	if err != nil {
+		return res, err
+	}
+	for _, condition := range jobConditions {
+		cond, ok := condition.(map[string]interface{})
+		if !ok || cond["status"] != "True" {
+			continue
+		}
+		if cond["type"] == "Complete" {
+			res.Completed = true
+		}
+		if msg, ok := cond["message"].(string); ok && msg != "" {
+			res.Message = msg
+		}
+	}
+
+	return res, nil
+}
+
+// WatchViewStatus watches the managedclusterview named viewName until it reports the job as
+// launched or complete depending on action, falling back to polling when watch isn't supported
+// returns:			error
+func (c Client) WatchViewStatus(ctx context.Context, clusterName string, viewName string, action string) error {
+	views := []ResourceTemplate{{ResourceName: viewName}}
+
+	gvr := c.servedGVR(ManagedClusterViewGVR)
+
+	opts := c.StatusOptions
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultCheckStatusOptions().Timeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	w, err := c.KubernetesClient.Resource(gvr).Namespace(clusterName).Watch(ctx, v1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("metadata.name", viewName).String(),
+	})
+	if errors.IsMethodNotSupported(err) {
+		c.logger().Debugf("watch isn't supported for %s, polling instead", MCV)
+		return c.waitForViewStatus(ctx, clusterName, views, action)
+	}
+	if err != nil {
+		return err
+	}
+	defer w.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				statusTimeouts.WithLabelValues(clusterName, action).Inc()
+				return fmt.Errorf("%w: couldn't verify the job was %s on cluster %s within %s", ErrViewTimeout, action, clusterName, opts.Timeout)
+			}
+			return ctx.Err()
+
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				// the server closed the watch, keep checking by polling
+				c.logger().Debugf("watch on %s %s closed, polling instead", MCV, viewName)
+				return c.waitForViewStatus(ctx, clusterName, views, action)
+			}
+			switch event.Type {
+			case watch.Error:
+				return errors.FromObject(event.Object)
+			case watch.Added, watch.Modified:
+				view, ok := event.Object.(*unstructured.Unstructured)
+				if !ok {
+					continue
+				}
+				if err := c.viewStatus(view, clusterName, action); err != nil {
+					if goerrors.Is(err, ErrJobFailed) {
+						return err
+					}
+					c.logger().Debugf("view %s not ready yet: %s", viewName, err)
+					continue
+				}
+				return nil
+			}
+		}
+	}
+}
+
+// ViewSummary is the name and decoded status of a managedclusterview
+type ViewSummary struct {
+	Name   string
+	Status ViewResult
+}
+
+// ListViews lists the managedclusterviews created by this client in the spoke namespace on the hub
+// returns:			[]ViewSummary, error
+func (c Client) ListViews(ctx context.Context, clusterName string) ([]ViewSummary, error) {
+	views, err := c.listOwned(ctx, clusterName, MCV)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]ViewSummary, 0, len(views))
+	for i := range views {
+		status, err := decodeViewResult(&views[i])
+		if err != nil {
+			return nil, fmt.Errorf("couldn't decode managedclusterview %s: %w", views[i].GetName(), err)
+		}
+		summaries = append(summaries, ViewSummary{Name: views[i].GetName(), Status: status})
+	}
+	return summaries, nil
+}
+
+// ExitCodeAnnotation is the annotation the backup job of backup-image sets on itself to report its exit code
+const ExitCodeAnnotation = "sno-upgrade-recovery/exit-code"
+
+// JobResult is the completion details of the job watched by a managedclusterview
+type JobResult struct {
+	// Available is false when the view has no job status yet, all the other fields are then zero
+	Available bool `json:"available"`
+	// Succeeded is true once the job reports the Complete condition
+	Succeeded bool `json:"succeeded"`
+	// ExitCode is read from the ExitCodeAnnotation of the job, zero when the job doesn't set it
+	ExitCode int `json:"exitCode"`
+	// Message is the message of the job Complete or Failed condition
+	Message string `json:"message,omitempty"`
+}
+
+// DecodeJobResult decodes the completion details of the job returned in a view result,
+// as returned by CheckStatusResult
+// returns:			JobResult
+func DecodeJobResult(result map[string]interface{}) JobResult {
+	var res JobResult
+
+	conditions, found, err := unstructured.NestedSlice(result, "status", "conditions")
+	if err != nil || !found {
+		return res
+	}
+	res.Available = true
+
+	for _, condition := range conditions {
+		cond, ok := condition.(map[string]interface{})
+		if !ok || cond["status"] != "True" {
+			continue
+		}
+		if cond["type"] != "Complete" && cond["type"] != "Failed" {
+			continue
+		}
+		res.Succeeded = cond["type"] == "Complete"
+		if msg, ok := cond["message"].(string); ok {
+			res.Message = msg
+		}
+	}
+
+	if code, found, _ := unstructured.NestedString(result, "metadata", "annotations", ExitCodeAnnotation); found {
+		if n, err := strconv.Atoi(code); err == nil {
+			res.ExitCode = n
+		}
+	}
+	return res
+}
+
+// GetJobResult checks the job status like CheckStatus and returns the completion details of the job
+// returns:			JobResult, error
+func (c Client) GetJobResult(ctx context.Context, clusterName string, action string) (JobResult, error) {
+	result, err := c.CheckStatusResult(ctx, MCV, clusterName, action)
+	if err != nil {
+		return JobResult{}, err
+	}
+	return DecodeJobResult(result), nil
+}
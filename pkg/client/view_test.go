@@ -0,0 +1,50 @@
+package client
+
+import (
+	"testing"
+)
+
+func TestDecodeJobResult(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		result map[string]interface{}
+		want   JobResult
+	}{
+		{
+			name:   "no job status yet",
+			result: map[string]interface{}{},
+			want:   JobResult{},
+		},
+		{
+			name:   "job running",
+			result: jobResult(nil, nil),
+			want:   JobResult{Available: true},
+		},
+		{
+			name:   "job complete",
+			result: jobResult(map[string]string{ExitCodeAnnotation: "0"}, condition("Complete", "True", "", "")),
+			want:   JobResult{Available: true, Succeeded: true},
+		},
+		{
+			name:   "job failed with its exit code",
+			result: jobResult(map[string]string{ExitCodeAnnotation: "3"}, condition("Failed", "True", "BackoffLimitExceeded", "Job has reached the specified backoff limit")),
+			want:   JobResult{Available: true, ExitCode: 3, Message: "Job has reached the specified backoff limit"},
+		},
+		{
+			name:   "unparsable exit code",
+			result: jobResult(map[string]string{ExitCodeAnnotation: "oops"}, condition("Failed", "True", "", "failed")),
+			want:   JobResult{Available: true, Message: "failed"},
+		},
+		{
+			name:   "false conditions are skipped",
+			result: jobResult(nil, condition("Complete", "False", "", "not yet"), condition("Suspended", "True", "", "suspended")),
+			want:   JobResult{Available: true},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := DecodeJobResult(tc.result); got != tc.want {
+				t.Errorf("got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}